@@ -28,16 +28,29 @@ type App struct {
 	DebugLogger  debug.Logger
 	ErrorLogger  debug.Logger
 	TgAPI        *tg.BotAPI
+	// InactiveChatTTL is the time after which the data of unreachable chats
+	// (e.g. when user blocked the bot) is deleted.
+	InactiveChatTTL time.Duration
+	// PostTopicTTL is the time after which the forum topic of the post without
+	// new updates is deleted (in the topics mode).
+	PostTopicTTL time.Duration
+	// SyncSaves enables the sync of the bookmarked posts with the FreeFeed
+	// saved posts.
+	SyncSaves bool
 
 	updChannel tg.UpdatesChannel
 	stateCache gcache.Cache
 	waitGroup  sync.WaitGroup
 	closeChan  chan struct{}
 
+	// Server-side saved posts of the chats, when the sync is enabled
+	remoteSavesCache gcache.Cache
+
 	rtConnLock sync.Mutex
 	rtConns    map[types.TgChatID]*socketio.Connection
 
 	pauseManager *PauseManager
+	sendQueue    *SendQueue
 }
 
 func (a *App) DebugLog() debug.Logger { return a.DebugLogger }
@@ -53,6 +66,14 @@ func (a *App) ContentOf(str string) string {
 	return str
 }
 
+func (a *App) SyncSavesEnabled() bool {
+	return a.SyncSaves
+}
+
+func (a *App) RemoteSavesCache() gcache.Cache {
+	return a.remoteSavesCache
+}
+
 // Start initializes the bot and starts the internal loops. This function doesnt
 // return until the cxt is cancelled.
 func (a *App) Start() (err error) {
@@ -65,6 +86,9 @@ func (a *App) Start() (err error) {
 			return state, nil
 		}).
 		Build()
+	// Keep the server-side list while user browses it, the posts saved on the
+	// site will be visible after the expiration
+	a.remoteSavesCache = gcache.New(1000).LRU().Expiration(10 * time.Minute).Build()
 
 	a.closeChan = make(chan struct{})
 
@@ -77,12 +101,27 @@ func (a *App) Start() (err error) {
 		debugLogger:     a.DebugLogger,
 	})
 
+	a.sendQueue = NewSendQueue(SendQueueCfg{
+		chatInterval:   time.Second,
+		groupInterval:  3 * time.Second,
+		globalInterval: time.Second / 30,
+		maxRetries:     3,
+		retryInterval:  time.Second,
+		send:           a.TgAPI.Send,
+		closeChan:      a.closeChan,
+		debugLogger:    a.DebugLogger,
+	})
+
 	a.updChannel = a.TgAPI.GetUpdatesChan(tg.UpdateConfig{Offset: 0, Timeout: 60})
 
 	a.waitGroup.Add(1)
 	a.DebugLogger.Println("▶️ Starting Telegram listener")
 	go a.listenTelegram()
 
+	go a.maintenanceLoop()
+	go a.watchesLoop()
+	go a.remindersLoop()
+
 	// Starting realtime connections for existing users
 	chatIDs, err := a.Store.ListIDs()
 	if err != nil {
@@ -92,20 +131,42 @@ func (a *App) Start() (err error) {
 	a.DebugLogger.Println("Chat IDs found:", chatIDs)
 
 	for _, chatID := range chatIDs {
+		// The broken data of one chat should not stop the others
+		quarantined, err := a.Store.QuarantineCorrupted(chatID)
+		if err != nil {
+			a.ErrorLogger.Printf("Cannot check data files of %d: %v", chatID, err)
+			continue
+		} else if len(quarantined) > 0 {
+			a.ErrorLogger.Printf("Corrupted data files of %d are quarantined: %v", chatID, quarantined)
+		}
+
 		state, err := a.Store.LoadState(chatID)
 		if err != nil {
-			a.ErrorLogger.Println("Cannot load state:", err)
-			return err
+			a.ErrorLogger.Printf("Cannot load state of %d: %v", chatID, err)
+			continue
 		}
 		state.ClearExpectations()
+		if state.TokenRevoked {
+			state.Expectation = store.ExpectAuthToken
+		}
 		if err := a.SaveState(state); err != nil {
-			a.ErrorLogger.Println("Cannot save state:", err)
-			return err
+			a.ErrorLogger.Printf("Cannot save state of %d: %v", chatID, err)
+			continue
 		}
 
+		if !state.IsActive() {
+			a.DebugLogger.Println("Chat is inactive, skipping", chatID)
+			continue
+		}
+		if state.TokenRevoked {
+			a.DebugLogger.Println("Access token is revoked, skipping", chatID)
+			continue
+		}
 		a.StartRealtime(chatID)
 	}
 
+	go a.migrateChats(chatIDs)
+
 	// Waiting for finish
 	a.waitGroup.Wait()
 	return nil
@@ -152,7 +213,7 @@ func (a *App) handleTgUpdate(update tg.Update) {
 	ch, err := chat.New(chatID, a)
 	if err != nil {
 		a.ErrorLogger.Printf("Error initiating chat #%d: %v", chatID, err)
-		a.TgAPI.Send(tg.NewMessage(chatID, internaErrorMsg))
+		a.Send(tg.NewMessage(chatID, internaErrorMsg))
 		return
 	}
 
@@ -191,8 +252,14 @@ func (a *App) SaveState(state *store.State) error {
 	return a.stateCache.Set(state.ID, state)
 }
 
+// Send sends the message through the rate-limited queue. It blocks until the
+// message is actually sent.
 func (a *App) Send(m tg.Chattable) (tg.Message, error) {
-	return a.TgAPI.Send(m)
+	msg, err := a.sendQueue.Send(m)
+	if chatID, ok := chatIDOf(m); ok {
+		err = a.checkUnreachable(chatID, err)
+	}
+	return msg, err
 }
 
 func (a *App) RTSend(chatID types.TgChatID, cmd string, payload interface{}, reply interface{}) error {
@@ -0,0 +1,139 @@
+package app
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/FreeFeed/freefeed-tg-client/types"
+	tg "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// The Telegram library doesn't support the forum topics, so we make the raw
+// requests here.
+
+// Maximum length of the topic name
+const maxTopicNameLength = 128
+
+type forumTopic struct {
+	MessageThreadID int    `json:"message_thread_id"`
+	Name            string `json:"name"`
+}
+
+// CreateForumTopic creates a new topic in the forum supergroup and returns its
+// thread ID.
+func (a *App) CreateForumTopic(chatID types.TgChatID, name string) (int, error) {
+	if runes := []rune(name); len(runes) > maxTopicNameLength {
+		name = string(runes[:maxTopicNameLength-1]) + "…"
+	}
+
+	params := make(tg.Params)
+	params.AddNonZero64("chat_id", chatID)
+	params.AddNonEmpty("name", name)
+
+	var topic forumTopic
+	_, err := a.sendRequest(chatID, func() (tg.Message, error) {
+		resp, err := a.TgAPI.MakeRequest("createForumTopic", params)
+		if err != nil {
+			return tg.Message{}, err
+		}
+		return tg.Message{}, json.Unmarshal(resp.Result, &topic)
+	})
+	return topic.MessageThreadID, err
+}
+
+// IsChatAdmin returns true if the user is the creator or an administrator of
+// the chat.
+func (a *App) IsChatAdmin(chatID types.TgChatID, userID int64) (bool, error) {
+	member, err := a.TgAPI.GetChatMember(tg.GetChatMemberConfig{
+		ChatConfigWithUser: tg.ChatConfigWithUser{ChatID: chatID, UserID: userID},
+	})
+	if err != nil {
+		return false, err
+	}
+	return member.IsCreator() || member.IsAdministrator(), nil
+}
+
+// DeleteForumTopic deletes the topic with all its messages.
+func (a *App) DeleteForumTopic(chatID types.TgChatID, threadID int) error {
+	params := make(tg.Params)
+	params.AddNonZero64("chat_id", chatID)
+	params.AddNonZero("message_thread_id", threadID)
+
+	_, err := a.sendRequest(chatID, func() (tg.Message, error) {
+		_, err := a.TgAPI.MakeRequest("deleteForumTopic", params)
+		return tg.Message{}, err
+	})
+	return err
+}
+
+// SendToTopic sends the text message to the given topic of the forum
+// supergroup.
+func (a *App) SendToTopic(msg *tg.MessageConfig, threadID int) (tg.Message, error) {
+	params := make(tg.Params)
+	params.AddNonZero64("chat_id", msg.ChatID)
+	params.AddNonZero("message_thread_id", threadID)
+	params.AddNonEmpty("text", msg.Text)
+	params.AddNonEmpty("parse_mode", msg.ParseMode)
+	params.AddBool("disable_web_page_preview", msg.DisableWebPagePreview)
+	params.AddNonZero("reply_to_message_id", msg.ReplyToMessageID)
+	params.AddBool("allow_sending_without_reply", msg.AllowSendingWithoutReply)
+	if err := params.AddInterface("reply_markup", msg.ReplyMarkup); err != nil {
+		return tg.Message{}, err
+	}
+
+	return a.sendRequest(msg.ChatID, func() (tg.Message, error) {
+		var message tg.Message
+		resp, err := a.TgAPI.MakeRequest("sendMessage", params)
+		if err != nil {
+			return message, err
+		}
+		return message, json.Unmarshal(resp.Result, &message)
+	})
+}
+
+func (a *App) sendRequest(chatID types.TgChatID, send func() (tg.Message, error)) (tg.Message, error) {
+	msg, err := a.sendQueue.SendRequest(chatID, send)
+	return msg, a.checkUnreachable(chatID, err)
+}
+
+// cleanupPostTopics deletes the topics of the posts that had no updates during
+// the PostTopicTTL.
+func (a *App) cleanupPostTopics() {
+	chatIDs, err := a.Store.ListIDs()
+	if err != nil {
+		a.ErrorLogger.Println("Cannot read chat IDs:", err)
+		return
+	}
+
+	for _, chatID := range chatIDs {
+		state, err := a.Store.LoadState(chatID)
+		if err != nil {
+			a.ErrorLogger.Printf("Cannot load state of %d: %v", chatID, err)
+			continue
+		}
+		if !state.TopicsMode || !state.IsActive() {
+			continue
+		}
+
+		topics, err := a.Store.ListPostTopics(chatID)
+		if err != nil {
+			a.ErrorLogger.Printf("Cannot load topics of %d: %v", chatID, err)
+			continue
+		}
+
+		for _, topic := range topics {
+			if time.Since(topic.UpdatedAt) < a.PostTopicTTL {
+				continue
+			}
+
+			a.DebugLogger.Printf("Deleting topic %d of post %s in %d", topic.ThreadID, topic.PostID, chatID)
+			if err := a.DeleteForumTopic(chatID, topic.ThreadID); err != nil {
+				// The topic may be already deleted by the group admin
+				a.ErrorLogger.Printf("Cannot delete topic %d in %d: %v", topic.ThreadID, chatID, err)
+			}
+			if err := a.Store.DeletePostTopic(chatID, topic.PostID); err != nil {
+				a.ErrorLogger.Printf("Cannot delete topic of post %s in %d: %v", topic.PostID, chatID, err)
+			}
+		}
+	}
+}
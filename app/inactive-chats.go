@@ -0,0 +1,102 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/FreeFeed/freefeed-tg-client/store"
+	"github.com/FreeFeed/freefeed-tg-client/types"
+	tg "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Descriptions of the 403 errors that mean the chat is unreachable. The other
+// 403 errors (e.g. no rights to send media) are related to the message only.
+var unreachableDescriptions = []string{
+	"bot was blocked by the user",
+	"user is deactivated",
+	"bot was kicked",
+}
+
+// isChatUnreachable returns true if the sending error means that the bot
+// cannot write to this chat anymore (user blocked the bot, chat was deleted,
+// etc.).
+func isChatUnreachable(err error) bool {
+	var tgErr *tg.Error
+	if !errors.As(err, &tgErr) {
+		return false
+	}
+	description := strings.ToLower(tgErr.Message)
+	switch tgErr.Code {
+	case http.StatusForbidden:
+		for _, d := range unreachableDescriptions {
+			if strings.Contains(description, d) {
+				return true
+			}
+		}
+	case http.StatusBadRequest:
+		return strings.Contains(description, "chat not found")
+	}
+	return false
+}
+
+// checkUnreachable deactivates the chat if the sending error means it is
+// unreachable. It returns the error wrapped with types.ErrChatUnreachable in
+// this case, so the chat can update its in-memory state.
+func (a *App) checkUnreachable(chatID types.TgChatID, err error) error {
+	if !isChatUnreachable(err) {
+		return err
+	}
+	a.deactivateChat(chatID)
+	return fmt.Errorf("%w: %w", types.ErrChatUnreachable, err)
+}
+
+// deactivateChat stops the realtime connection of the unreachable chat and
+// marks its state as inactive. The state will be deleted after the
+// InactiveChatTTL, unless the user comes back with the /start command.
+func (a *App) deactivateChat(chatID types.TgChatID) {
+	state, err := a.Store.LoadState(chatID)
+	if errors.Is(err, store.ErrNotFound) {
+		return
+	} else if err != nil {
+		a.ErrorLogger.Printf("Cannot load state of %d: %v", chatID, err)
+		return
+	}
+
+	if !state.IsActive() {
+		return
+	}
+
+	a.DebugLogger.Println("Chat is unreachable, deactivating", chatID)
+	a.StopRealtime(chatID)
+	state.Deactivate()
+	if err := a.Store.SaveState(state); err != nil {
+		a.ErrorLogger.Printf("Cannot save state of %d: %v", chatID, err)
+	}
+}
+
+func (a *App) deleteInactiveChats() {
+	chatIDs, err := a.Store.ListIDs()
+	if err != nil {
+		a.ErrorLogger.Println("Cannot read chat IDs:", err)
+		return
+	}
+
+	for _, chatID := range chatIDs {
+		state, err := a.Store.LoadState(chatID)
+		if err != nil {
+			a.ErrorLogger.Printf("Cannot load state of %d: %v", chatID, err)
+			continue
+		}
+		if state.IsActive() || time.Since(state.DeactivatedAt) < a.InactiveChatTTL {
+			continue
+		}
+
+		a.DebugLogger.Println("Deleting inactive chat", chatID)
+		if err := a.Store.DeleteState(chatID); err != nil {
+			a.ErrorLogger.Printf("Cannot delete state of %d: %v", chatID, err)
+		}
+	}
+}
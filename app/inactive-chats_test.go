@@ -0,0 +1,31 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsChatUnreachable(t *testing.T) {
+	tests := []struct {
+		err         error
+		unreachable bool
+	}{
+		{&tg.Error{Code: http.StatusForbidden, Message: "Forbidden: bot was blocked by the user"}, true},
+		{&tg.Error{Code: http.StatusForbidden, Message: "Forbidden: user is deactivated"}, true},
+		{&tg.Error{Code: http.StatusForbidden, Message: "Forbidden: bot was kicked from the supergroup chat"}, true},
+		{fmt.Errorf("send: %w", &tg.Error{Code: http.StatusForbidden, Message: "Forbidden: bot was blocked by the user"}), true},
+		{&tg.Error{Code: http.StatusBadRequest, Message: "Bad Request: chat not found"}, true},
+		{&tg.Error{Code: http.StatusForbidden, Message: "Forbidden: not enough rights to send photos to the chat"}, false},
+		{&tg.Error{Code: http.StatusBadRequest, Message: "Bad Request: message is too long"}, false},
+		{errors.New("network error"), false},
+		{nil, false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.unreachable, isChatUnreachable(tt.err), "%v", tt.err)
+	}
+}
@@ -2,11 +2,15 @@ package app
 
 import (
 	"fmt"
+	"net/url"
 	"regexp"
 	"strings"
-)
+	"unicode"
+	"unicode/utf8"
 
-var mentionsRe = regexp.MustCompile(`@[a-z0-9-]+`)
+	"github.com/FreeFeed/freefeed-tg-client/frf"
+	"github.com/gofrs/uuid"
+)
 
 // Telegram understands only these entities
 var htmlEscaper = strings.NewReplacer(
@@ -15,23 +19,248 @@ var htmlEscaper = strings.NewReplacer(
 	`>`, "&gt;",
 )
 
+var attrEscaper = strings.NewReplacer(
+	`&`, "&amp;",
+	`<`, "&lt;",
+	`>`, "&gt;",
+	`"`, "&quot;",
+)
+
+var linkifyRe = regexp.MustCompile(`(?i)` +
+	`(https?://[^\s<>"]+)` + // URL
+	`|(@[a-z0-9]+(?:-[a-z0-9]+)*)` + // mention
+	`|(#[\p{L}\p{N}_]+(?:-[\p{L}\p{N}_]+)*)` + // hashtag
+	`|(\^+|↑+)` + // comment back-reference
+	`|(</?spoiler>|\[/?spoiler\])`, // spoiler
+)
+
+const (
+	tokURL = iota + 1
+	tokMention
+	tokHashtag
+	tokBackref
+	tokSpoiler
+)
+
+// Characters that are not a part of URL at its end
+const urlTrailingChars = `.,:;!?'"»)`
+
+// Maximum length of the displayed URL
+const maxURLTextLength = 40
+
+type linkifyToken struct {
+	kind       int
+	start, end int
+	text       string
+}
+
+// Linkify converts the FreeFeed text to the Telegram HTML: escapes the HTML
+// entities and turns the mentions, URLs, hashtags and spoilers to the Telegram
+// markup.
 func (a *App) Linkify(text string) string {
-	var result strings.Builder
+	return a.linkify(text, nil, uuid.Nil)
+}
+
+// LinkifyComment works like Linkify and also turns the back-references (^ or
+// ↑) to the links to the referenced comments of the post.
+func (a *App) LinkifyComment(text string, post *frf.Post, commentID uuid.UUID) string {
+	return a.linkify(text, post, commentID)
+}
+
+func (a *App) linkify(text string, post *frf.Post, commentID uuid.UUID) string {
+	tokens := tokenizeText(text)
+
+	// Only the paired spoiler tags are converted
+	spoilers := make(map[int]bool)
+	openIdx := -1
+	for i, tok := range tokens {
+		if tok.kind != tokSpoiler {
+			continue
+		}
+		isClosing := strings.Contains(tok.text, "/")
+		if !isClosing && openIdx < 0 {
+			openIdx = i
+		} else if isClosing && openIdx >= 0 {
+			spoilers[openIdx] = true
+			spoilers[i] = true
+			openIdx = -1
+		}
+	}
 
-	founds := mentionsRe.FindAllStringSubmatchIndex(text, -1)
+	var result strings.Builder
 	prev := 0
-	for _, loc := range founds {
-		result.WriteString(htmlEscaper.Replace(text[prev:loc[0]]))
-		username := text[loc[0]+1 : loc[1]]
-		result.WriteString(fmt.Sprintf(
-			`<a href="https://%s/%s">@%s</a>`,
-			a.FreeFeedHost,
-			username,
-			username,
-		))
-		prev = loc[1]
+	for i, tok := range tokens {
+		result.WriteString(htmlEscaper.Replace(text[prev:tok.start]))
+		prev = tok.end
+
+		switch tok.kind {
+		case tokURL:
+			result.WriteString(a.formatURL(tok.text))
+		case tokMention:
+			username := strings.ToLower(tok.text[1:])
+			result.WriteString(fmt.Sprintf(
+				`<a href="https://%s/%s">%s</a>`,
+				a.FreeFeedHost,
+				username,
+				tok.text,
+			))
+		case tokHashtag:
+			result.WriteString(fmt.Sprintf(
+				`<a href="https://%s/search?qs=%s">%s</a>`,
+				a.FreeFeedHost,
+				attrEscaper.Replace(url.QueryEscape(tok.text)),
+				htmlEscaper.Replace(tok.text),
+			))
+		case tokBackref:
+			if refID := backrefTarget(post, commentID, utf8.RuneCountInString(tok.text)); refID != uuid.Nil {
+				result.WriteString(fmt.Sprintf(
+					`<a href="https://%s/posts/%s#comment-%s">%s</a>`,
+					a.FreeFeedHost,
+					post.ID,
+					refID,
+					tok.text,
+				))
+			} else {
+				result.WriteString(tok.text)
+			}
+		case tokSpoiler:
+			if !spoilers[i] {
+				result.WriteString(htmlEscaper.Replace(tok.text))
+			} else if strings.Contains(tok.text, "/") {
+				result.WriteString("</tg-spoiler>")
+			} else {
+				result.WriteString("<tg-spoiler>")
+			}
+		}
 	}
 
 	result.WriteString(htmlEscaper.Replace(text[prev:]))
 	return result.String()
 }
+
+func tokenizeText(text string) []linkifyToken {
+	var tokens []linkifyToken
+	for offset := 0; offset < len(text); {
+		loc := linkifyRe.FindStringSubmatchIndex(text[offset:])
+		if loc == nil {
+			break
+		}
+
+		tok := linkifyToken{start: offset + loc[0], end: offset + loc[1]}
+		for kind := tokURL; kind <= tokSpoiler; kind++ {
+			if loc[2*kind] >= 0 {
+				tok.kind = kind
+				break
+			}
+		}
+
+		if tok.kind == tokURL {
+			tok.end = tok.start + urlLength(text[tok.start:tok.end])
+		}
+		tok.text = text[tok.start:tok.end]
+
+		if isTokenBounded(text, tok) {
+			tokens = append(tokens, tok)
+			offset = tok.end
+		} else {
+			// Skip the first character and search again
+			_, size := utf8.DecodeRuneInString(text[tok.start:])
+			offset = tok.start + size
+		}
+	}
+	return tokens
+}
+
+// isTokenBounded checks that the token is not a part of some word (like the
+// '@' in email).
+func isTokenBounded(text string, tok linkifyToken) bool {
+	if tok.kind == tokURL || tok.kind == tokSpoiler {
+		return true
+	}
+
+	before, _ := utf8.DecodeLastRuneInString(text[:tok.start])
+	if tok.start > 0 && isWordChar(before) {
+		return false
+	}
+
+	after, _ := utf8.DecodeRuneInString(text[tok.end:])
+	if tok.end < len(text) && isWordChar(after) {
+		return false
+	}
+
+	return true
+}
+
+func isWordChar(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// urlLength returns the length of URL without the trailing punctuation. The
+// closing parenthesis is kept if it has a pair inside the URL.
+func urlLength(u string) int {
+	for len(u) > 0 {
+		last, size := utf8.DecodeLastRuneInString(u)
+		if !strings.ContainsRune(urlTrailingChars, last) {
+			break
+		}
+		if last == ')' && strings.Count(u, "(") >= strings.Count(u, ")") {
+			break
+		}
+		u = u[:len(u)-size]
+	}
+	return len(u)
+}
+
+var frfPostPathRe = regexp.MustCompile(`^/([a-z0-9-]+)/([0-9a-f]{6,10}|[0-9a-f-]{36})/?$`)
+var frfCommentHashRe = regexp.MustCompile(`^(?:comment-)?([0-9a-f]{4,10}|[0-9a-f-]{36})$`)
+
+// formatURL returns the HTML link with the short URL text. The links to the
+// FreeFeed posts and comments are shown as "/username/postID#commentID".
+func (a *App) formatURL(rawURL string) string {
+	text := ""
+
+	if u, err := url.Parse(rawURL); err == nil &&
+		strings.TrimPrefix(strings.ToLower(u.Host), "www.") == a.FreeFeedHost {
+		if m := frfPostPathRe.FindStringSubmatch(u.Path); m != nil {
+			text = "/" + m[1] + "/" + shortID(m[2])
+			if m := frfCommentHashRe.FindStringSubmatch(u.Fragment); m != nil {
+				text += "#" + shortID(m[1])
+			}
+		}
+	}
+
+	if text == "" {
+		text = rawURL[strings.Index(rawURL, "://")+3:]
+		text = strings.TrimPrefix(text, "www.")
+		if utf8.RuneCountInString(text) > maxURLTextLength {
+			text = string([]rune(text)[:maxURLTextLength-1]) + "…"
+		}
+	}
+
+	return fmt.Sprintf(`<a href="%s">%s</a>`, attrEscaper.Replace(rawURL), htmlEscaper.Replace(text))
+}
+
+// shortID shortens the UUIDs to the first 6 characters.
+func shortID(id string) string {
+	if len(id) > 10 {
+		return id[:6]
+	}
+	return id
+}
+
+// backrefTarget returns the ID of the comment referenced by the back-reference
+// of the given length, or uuid.Nil if it cannot be found.
+func backrefTarget(post *frf.Post, commentID uuid.UUID, length int) uuid.UUID {
+	if post == nil {
+		return uuid.Nil
+	}
+	for i, c := range post.Comments {
+		if c.ID == commentID {
+			if i-length >= 0 {
+				return post.Comments[i-length].ID
+			}
+			break
+		}
+	}
+	return uuid.Nil
+}
@@ -3,6 +3,8 @@ package app
 import (
 	"testing"
 
+	"github.com/FreeFeed/freefeed-tg-client/frf"
+	"github.com/gofrs/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -26,3 +28,148 @@ func TestLinkify(t *testing.T) {
 		a.Linkify(`<big>hello&</big>, @user-name!`),
 	)
 }
+
+func TestLinkifyTable(t *testing.T) {
+	a := &App{FreeFeedHost: "freefeed.net"}
+
+	tests := []struct {
+		name string
+		text string
+		html string
+	}{
+		// Mentions
+		{
+			"uppercase mention",
+			`hello, @UserName`,
+			`hello, <a href="https://freefeed.net/username">@UserName</a>`,
+		},
+		{
+			"mention with trailing punctuation",
+			`@user-name-, @user.`,
+			`<a href="https://freefeed.net/user-name">@user-name</a>-, <a href="https://freefeed.net/user">@user</a>.`,
+		},
+		{
+			"email is not a mention",
+			`write to user@example.com`,
+			`write to user@example.com`,
+		},
+		// URLs
+		{
+			"bare URL",
+			`see https://example.com/page?a=1&b=2.`,
+			`see <a href="https://example.com/page?a=1&amp;b=2">example.com/page?a=1&amp;b=2</a>.`,
+		},
+		{
+			"URL in parentheses",
+			`(see https://en.wikipedia.org/wiki/Go_(language))`,
+			`(see <a href="https://en.wikipedia.org/wiki/Go_(language)">en.wikipedia.org/wiki/Go_(language)</a>)`,
+		},
+		{
+			"long URL",
+			`https://www.example.com/a/very/long/path/to/some/resource/on/the/site`,
+			`<a href="https://www.example.com/a/very/long/path/to/some/resource/on/the/site">example.com/a/very/long/path/to/some/re…</a>`,
+		},
+		{
+			"mention in URL",
+			`https://example.com/@user`,
+			`<a href="https://example.com/@user">example.com/@user</a>`,
+		},
+		{
+			"FreeFeed post",
+			`https://freefeed.net/user/1a2b3c`,
+			`<a href="https://freefeed.net/user/1a2b3c">/user/1a2b3c</a>`,
+		},
+		{
+			"FreeFeed post with UUID",
+			`https://freefeed.net/user/2f9e8b7c-1d2a-4b3c-9e8f-7a6b5c4d3e2f`,
+			`<a href="https://freefeed.net/user/2f9e8b7c-1d2a-4b3c-9e8f-7a6b5c4d3e2f">/user/2f9e8b</a>`,
+		},
+		{
+			"FreeFeed comment",
+			`https://freefeed.net/user/1a2b3c#ab12`,
+			`<a href="https://freefeed.net/user/1a2b3c#ab12">/user/1a2b3c#ab12</a>`,
+		},
+		// Hashtags
+		{
+			"hashtag",
+			`#hello and #мир-труд!`,
+			`<a href="https://freefeed.net/search?qs=%23hello">#hello</a> and <a href="https://freefeed.net/search?qs=%23%D0%BC%D0%B8%D1%80-%D1%82%D1%80%D1%83%D0%B4">#мир-труд</a>!`,
+		},
+		{
+			"not a hashtag",
+			`C# language`,
+			`C# language`,
+		},
+		// Spoilers
+		{
+			"spoiler",
+			`it is <spoiler>secret</spoiler>`,
+			`it is <tg-spoiler>secret</tg-spoiler>`,
+		},
+		{
+			"square brackets spoiler",
+			`it is [spoiler]secret @user[/spoiler]`,
+			`it is <tg-spoiler>secret <a href="https://freefeed.net/user">@user</a></tg-spoiler>`,
+		},
+		{
+			"unpaired spoiler",
+			`it is <spoiler>secret`,
+			`it is &lt;spoiler&gt;secret`,
+		},
+		// Back-references without context
+		{
+			"back-reference",
+			`^^ agree`,
+			`^^ agree`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.html, a.Linkify(tt.text))
+		})
+	}
+}
+
+func TestLinkifyComment(t *testing.T) {
+	a := &App{FreeFeedHost: "freefeed.net"}
+
+	post := &frf.Post{ID: uuid.Must(uuid.NewV4())}
+	for i := 0; i < 3; i++ {
+		post.Comments = append(post.Comments, frf.Comment{ID: uuid.Must(uuid.NewV4())})
+	}
+	commentID := post.Comments[2].ID
+
+	tests := []struct {
+		name string
+		text string
+		html string
+	}{
+		{
+			"one arrow",
+			`^ agree`,
+			`<a href="https://freefeed.net/posts/` + post.ID.String() + `#comment-` + post.Comments[1].ID.String() + `">^</a> agree`,
+		},
+		{
+			"two arrows",
+			`↑↑ agree`,
+			`<a href="https://freefeed.net/posts/` + post.ID.String() + `#comment-` + post.Comments[0].ID.String() + `">↑↑</a> agree`,
+		},
+		{
+			"too many arrows",
+			`^^^ agree`,
+			`^^^ agree`,
+		},
+		{
+			"not a back-reference",
+			`2^3`,
+			`2^3`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.html, a.LinkifyComment(tt.text, post, commentID))
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package app
+
+import "time"
+
+const maintenanceInterval = time.Hour
+
+// maintenanceLoop periodically performs the housekeeping tasks for all chats.
+func (a *App) maintenanceLoop() {
+	a.DebugLogger.Println("▶️ Starting maintenance loop")
+	defer a.DebugLogger.Println("⏹️ Stopping maintenance loop")
+
+	ticker := time.NewTicker(maintenanceInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			a.deleteInactiveChats()
+			a.checkTokensExpiry()
+			a.cleanupPostTopics()
+			a.deleteExpiredMutes()
+			a.compactSentRecords()
+		case <-a.closeChan:
+			return
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/FreeFeed/freefeed-tg-client/frf"
+	"github.com/FreeFeed/freefeed-tg-client/store"
+	"github.com/FreeFeed/freefeed-tg-client/types"
+	"github.com/gofrs/uuid"
+)
+
+// Version of the chat data format. Increase it when adding a new migration to
+// migrateChat.
+const currentMigrationVersion = 1
+
+// Maximum time of the migration of one chat. The unfinished migration is
+// repeated on the next start.
+const migrationChatTimeout = 2 * time.Minute
+
+// commentsNotifier is the part of the FreeFeed API used by the migrations
+type commentsNotifier interface {
+	NotifyOfAllComments(postID uuid.UUID, enabled bool) (bool, error)
+}
+
+// migrateChats performs the one-time migrations of the chats data. It is
+// called in background, so the slow FreeFeed responses don't delay the start
+// of the other chats.
+func (a *App) migrateChats(chatIDs []types.TgChatID) {
+	for _, chatID := range chatIDs {
+		select {
+		case <-a.closeChan:
+			return
+		default:
+		}
+
+		state, err := a.Store.LoadState(chatID)
+		if err != nil {
+			a.ErrorLogger.Printf("Cannot load state of %d: %v", chatID, err)
+			continue
+		}
+		if state.MigrationVersion >= currentMigrationVersion || !state.IsAuthorized() || state.TokenRevoked {
+			continue
+		}
+
+		api := a.FreeFeedAPI()
+		api.AccessToken = state.AccessToken
+
+		ctx, cancel := context.WithTimeout(context.Background(), migrationChatTimeout)
+		a.migrateChat(ctx, state, api)
+		cancel()
+	}
+}
+
+// migrateChat performs the migrations of one chat and saves its new
+// MigrationVersion.
+func (a *App) migrateChat(ctx context.Context, state *store.State, api commentsNotifier) {
+	chatID := state.ID
+	version := state.MigrationVersion
+
+	if version < 1 {
+		if !a.migrateTrackedPosts(ctx, chatID, api) {
+			return
+		}
+		version = 1
+		// The migrated posts should not be subscribed in realtime anymore
+		a.resubscribeRealtime(chatID)
+	}
+
+	// The state may be changed by the chat while migrating, so load the
+	// fresh one
+	state, err := a.Store.LoadState(chatID)
+	if err != nil {
+		a.ErrorLogger.Printf("Cannot load state of %d: %v", chatID, err)
+		return
+	}
+	state.MigrationVersion = version
+	if err := a.Store.SaveState(state); err != nil {
+		a.ErrorLogger.Printf("Cannot save state of %d: %v", chatID, err)
+	}
+}
+
+// migrateTrackedPosts moves the legacy tracked posts (subscribed via realtime)
+// to the server-side NotifyOfAllComments flag. It returns false if some posts
+// cannot be migrated now and the migration should be repeated later.
+func (a *App) migrateTrackedPosts(ctx context.Context, chatID types.TgChatID, api commentsNotifier) bool {
+	tracked, err := a.Store.TrackedEntities(chatID)
+	if err != nil {
+		a.ErrorLogger.Printf("Cannot load tracked posts of %d: %v", chatID, err)
+		return false
+	}
+	if len(tracked.PostIDs) == 0 {
+		return true
+	}
+
+	var enabled, unavailable, failed int
+	for _, postID := range tracked.PostIDs {
+		if ctx.Err() != nil {
+			// Out of time, the rest of posts will be migrated later
+			failed++
+			continue
+		}
+
+		_, err := api.NotifyOfAllComments(postID, true)
+		if frf.IsUnauthorized(err) {
+			a.ErrorLogger.Printf("Cannot migrate tracked posts of %d: token is not valid", chatID)
+			return false
+		} else if frf.IsNotAccessible(err) {
+			// Post is deleted or hidden, just forget it
+			unavailable++
+		} else if err != nil {
+			a.ErrorLogger.Printf("Cannot migrate tracked post %s of %d: %v", postID, chatID, err)
+			failed++
+			continue
+		} else {
+			enabled++
+			if err := a.Store.SetPostCommentsNotify(chatID, postID, true); err != nil {
+				a.ErrorLogger.Printf("Cannot save tracked post %s of %d: %v", postID, chatID, err)
+			}
+		}
+
+		if err := a.Store.UntrackPost(chatID, postID); err != nil {
+			a.ErrorLogger.Printf("Cannot untrack post %s of %d: %v", postID, chatID, err)
+			failed++
+		}
+	}
+
+	logger := a.DebugLogger
+	if failed > 0 {
+		logger = a.ErrorLogger
+	}
+	logger.Printf(
+		"Tracked posts of %d are migrated: %d enabled, %d unavailable, %d failed",
+		chatID, enabled, unavailable, failed,
+	)
+	return failed == 0
+}
+
+// resubscribeRealtime restarts the realtime connection of the chat (if it is
+// running) to update its subscriptions.
+func (a *App) resubscribeRealtime(chatID types.TgChatID) {
+	a.rtConnLock.Lock()
+	_, ok := a.rtConns[chatID]
+	a.rtConnLock.Unlock()
+
+	if ok {
+		a.StartRealtime(chatID)
+	}
+}
@@ -0,0 +1,129 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/FreeFeed/freefeed-tg-client/frf"
+	"github.com/FreeFeed/freefeed-tg-client/store"
+	"github.com/davidmz/debug-log"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeNotifier struct {
+	errors map[uuid.UUID]error
+	calls  []uuid.UUID
+}
+
+func (f *fakeNotifier) NotifyOfAllComments(postID uuid.UUID, enabled bool) (bool, error) {
+	f.calls = append(f.calls, postID)
+	if err := f.errors[postID]; err != nil {
+		return false, err
+	}
+	return enabled, nil
+}
+
+func newMigrationTestApp(t *testing.T, postIDs ...uuid.UUID) (*App, *store.State) {
+	a := &App{
+		Store:       store.NewFsStore(t.TempDir()),
+		DebugLogger: debug.NewLogger("test"),
+		ErrorLogger: debug.NewLogger("test:error"),
+	}
+	state := &store.State{ID: 123, AccessToken: "token"}
+	require.NoError(t, a.Store.SaveState(state))
+	for _, postID := range postIDs {
+		require.NoError(t, a.Store.TrackPost(state.ID, postID))
+	}
+	return a, state
+}
+
+func TestMigrateTrackedPosts(t *testing.T) {
+	enabledID := uuid.Must(uuid.NewV4())
+	deletedID := uuid.Must(uuid.NewV4())
+	failedID := uuid.Must(uuid.NewV4())
+
+	a, state := newMigrationTestApp(t, enabledID, deletedID, failedID)
+	api := &fakeNotifier{errors: map[uuid.UUID]error{
+		deletedID: &frf.Error{HTTPStatusCode: http.StatusNotFound},
+		failedID:  errors.New("network error"),
+	}}
+
+	a.migrateChat(context.Background(), state, api)
+
+	tracked, err := a.Store.TrackedEntities(state.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{failedID}, tracked.PostIDs)
+	assert.Equal(t, []uuid.UUID{enabledID}, tracked.CommentPostIDs)
+
+	// Failed post keeps the migration unfinished
+	state, err = a.Store.LoadState(state.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, state.MigrationVersion)
+
+	// The next attempt retries only the failed post
+	api.errors = nil
+	api.calls = nil
+	a.migrateChat(context.Background(), state, api)
+	assert.Equal(t, []uuid.UUID{failedID}, api.calls)
+
+	tracked, err = a.Store.TrackedEntities(state.ID)
+	require.NoError(t, err)
+	assert.Empty(t, tracked.PostIDs)
+	assert.ElementsMatch(t, []uuid.UUID{enabledID, failedID}, tracked.CommentPostIDs)
+
+	state, err = a.Store.LoadState(state.ID)
+	require.NoError(t, err)
+	assert.Equal(t, currentMigrationVersion, state.MigrationVersion)
+}
+
+func TestMigrateTrackedPostsUnauthorized(t *testing.T) {
+	postID := uuid.Must(uuid.NewV4())
+	postID2 := uuid.Must(uuid.NewV4())
+
+	a, state := newMigrationTestApp(t, postID, postID2)
+	api := &fakeNotifier{errors: map[uuid.UUID]error{
+		postID: &frf.Error{HTTPStatusCode: http.StatusUnauthorized},
+	}}
+
+	a.migrateChat(context.Background(), state, api)
+
+	// Migration stops at the first unauthorized response
+	assert.Equal(t, []uuid.UUID{postID}, api.calls)
+
+	tracked, err := a.Store.TrackedEntities(state.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{postID, postID2}, tracked.PostIDs)
+
+	state, err = a.Store.LoadState(state.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, state.MigrationVersion)
+}
+
+func TestMigrateTrackedPostsTimeout(t *testing.T) {
+	postID := uuid.Must(uuid.NewV4())
+
+	a, state := newMigrationTestApp(t, postID)
+	api := &fakeNotifier{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	a.migrateChat(ctx, state, api)
+
+	assert.Empty(t, api.calls)
+	state, err := a.Store.LoadState(state.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, state.MigrationVersion)
+}
+
+func TestMigrateWithoutTrackedPosts(t *testing.T) {
+	a, state := newMigrationTestApp(t)
+	a.migrateChat(context.Background(), state, &fakeNotifier{})
+
+	state, err := a.Store.LoadState(state.ID)
+	require.NoError(t, err)
+	assert.Equal(t, currentMigrationVersion, state.MigrationVersion)
+}
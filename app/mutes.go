@@ -0,0 +1,23 @@
+package app
+
+import "time"
+
+// deleteExpiredMutes removes the expired mutes of all chats. The expired mutes
+// are ignored anyway, so this is just a cleanup.
+func (a *App) deleteExpiredMutes() {
+	chatIDs, err := a.Store.ListIDs()
+	if err != nil {
+		a.ErrorLogger.Println("Cannot read chat IDs:", err)
+		return
+	}
+
+	now := time.Now()
+	for _, chatID := range chatIDs {
+		deleted, err := a.Store.DeleteExpiredMutes(chatID, now)
+		if err != nil {
+			a.ErrorLogger.Printf("Cannot delete expired mutes of %d: %v", chatID, err)
+		} else if deleted > 0 {
+			a.DebugLogger.Printf("Deleted %d expired mutes of %d", deleted, chatID)
+		}
+	}
+}
@@ -81,12 +81,25 @@ func (a *App) onRTConnect(chatID types.TgChatID, rt *socketio.Connection) {
 	reply := try.ItVal(rt.Send("auth", authTokenPayload{state.AccessToken}))
 	logger.Println("Auth reply:", string(reply))
 
+	var authReply []rtReply
+	if err := json.Unmarshal(reply, &authReply); err == nil &&
+		len(authReply) > 0 && !authReply[0].Success {
+		// Realtime server doesn't tell us the reason, so check the token via
+		// API. If it is revoked, the realtime connection will be stopped.
+		ch := try.ItVal(chat.New(chatID, a))
+		if err := ch.CheckToken(); frf.IsUnauthorized(err) {
+			return
+		}
+		try.Throw(fmt.Errorf("auth failed: %s", authReply[0].Message))
+	}
+
 	tracked := try.ItVal(a.TrackedEntities(chatID))
 	reply = try.ItVal(rt.Send(
 		"subscribe",
 		types.UserSubsPayload{
-			UserIDs: []uuid.UUID{state.UserID},
-			PostIDs: tracked.PostIDs,
+			UserIDs:     []uuid.UUID{state.UserID},
+			PostIDs:     tracked.PostIDs,
+			TimelineIDs: tracked.FeedIDs(),
 		},
 	))
 	logger.Println("Subscribe reply:", string(reply))
@@ -122,6 +135,24 @@ func (a *App) onRTMessage(chatID types.TgChatID, msg socketio.IncomingMessage) {
 			}
 		}
 
+		events = frf.Events{event}
+	} else if msg.Type == "post:new" {
+		// New post in the followed timeline
+		var pEvent frf.NewPostEvent
+		try.It(json.Unmarshal(msg.Payload, &pEvent))
+
+		event := &frf.Event{
+			Type:          "__" + msg.Type,
+			PostID:        pEvent.Posts.ID,
+			CreatedUserID: pEvent.Posts.CreatedBy,
+		}
+		for _, u := range pEvent.Users {
+			if u.ID == event.CreatedUserID {
+				event.CreatedUser = u
+				break
+			}
+		}
+
 		events = frf.Events{event}
 	}
 
@@ -137,3 +168,8 @@ func (a *App) onRTMessage(chatID types.TgChatID, msg socketio.IncomingMessage) {
 type authTokenPayload struct {
 	AuthToken string `json:"authToken"`
 }
+
+type rtReply struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
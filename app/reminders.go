@@ -0,0 +1,94 @@
+package app
+
+import (
+	"errors"
+	"time"
+
+	"github.com/FreeFeed/freefeed-tg-client/chat"
+	"github.com/FreeFeed/freefeed-tg-client/store"
+	"github.com/FreeFeed/freefeed-tg-client/types"
+)
+
+// How often to look for the due reminders
+const remindersCheckInterval = 30 * time.Second
+
+// remindersLoop sends the due reminders of all chats. The reminders are kept
+// in the store, so the ones that became due during the downtime are sent
+// soon after the start.
+func (a *App) remindersLoop() {
+	a.DebugLogger.Println("▶️ Starting reminders loop")
+	defer a.DebugLogger.Println("⏹️ Stopping reminders loop")
+
+	ticker := time.NewTicker(remindersCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			a.sendDueReminders()
+		case <-a.closeChan:
+			return
+		}
+	}
+}
+
+func (a *App) sendDueReminders() {
+	chatIDs, err := a.Store.ListIDs()
+	if err != nil {
+		a.ErrorLogger.Println("Cannot read chat IDs:", err)
+		return
+	}
+
+	now := time.Now()
+	for _, chatID := range chatIDs {
+		reminders, err := a.Store.ListReminders(chatID)
+		if err != nil {
+			a.ErrorLogger.Printf("Cannot load reminders of %d: %v", chatID, err)
+			continue
+		}
+
+		var due []store.Reminder
+		for _, r := range reminders {
+			if !now.Before(r.FireAt) {
+				due = append(due, r)
+			}
+		}
+		if len(due) > 0 {
+			a.sendReminders(chatID, due)
+		}
+
+		select {
+		case <-a.closeChan:
+			return
+		default:
+		}
+	}
+}
+
+func (a *App) sendReminders(chatID types.TgChatID, reminders []store.Reminder) {
+	ch, err := chat.New(chatID, a)
+	if err != nil {
+		a.ErrorLogger.Printf("Cannot create chat %d: %v", chatID, err)
+		return
+	}
+	if !ch.State.IsAuthorized() || !ch.State.IsActive() || ch.State.TokenRevoked {
+		// Reminders will wait for the chat to come back
+		return
+	}
+
+	for _, r := range reminders {
+		// Delete the reminder first, so it will not be sent twice if something
+		// goes wrong
+		err := a.Store.DeleteReminder(chatID, r.ID)
+		if errors.Is(err, store.ErrNotFound) {
+			// Cancelled just now
+			continue
+		} else if err != nil {
+			a.ErrorLogger.Printf("Cannot delete reminder of %d: %v", chatID, err)
+			continue
+		}
+
+		a.DebugLogger.Printf("Sending reminder %s to %d", r.ID, chatID)
+		ch.SendReminder(&r)
+	}
+}
@@ -0,0 +1,311 @@
+package app
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/FreeFeed/freefeed-tg-client/types"
+	"github.com/davidmz/debug-log"
+	tg "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+var errSendQueueClosed = errors.New("send queue is closed")
+
+type SendQueueCfg struct {
+	// Minimal interval between two messages to the same private chat
+	chatInterval time.Duration
+	// Minimal interval between two messages to the same group chat
+	groupInterval time.Duration
+	// Minimal interval between any two requests of the bot
+	globalInterval time.Duration
+	// How many times to retry the transient failures
+	maxRetries int
+	// Initial retry interval, it doubles on every attempt
+	retryInterval time.Duration
+	send          func(tg.Chattable) (tg.Message, error)
+	closeChan     <-chan struct{}
+	debugLogger   debug.Logger
+}
+
+// SendQueue is the outgoing messages scheduler. It keeps the Telegram rate
+// limits, retries the transient failures and preserves the order of messages
+// in every chat.
+type SendQueue struct {
+	SendQueueCfg
+	lock   sync.Mutex
+	chats  map[types.TgChatID]*chatSendQueue
+	tokens chan struct{}
+	// No requests are sent until this time (Telegram asked to wait by the
+	// request that is not bound to any chat)
+	pausedUntil time.Time
+}
+
+// chatSendQueue exists only while the chat has the pending messages or the
+// chat interval since the last message is not passed yet.
+type chatSendQueue struct {
+	jobs     []*sendJob
+	lastSent time.Time
+	// No messages are sent to the chat until this time
+	pausedUntil time.Time
+}
+
+type sendJob struct {
+	send   func() (tg.Message, error)
+	result chan sendResult
+	// The queue of the chat, nil for the requests without chat
+	chat *chatSendQueue
+}
+
+type sendResult struct {
+	msg tg.Message
+	err error
+}
+
+func NewSendQueue(cfg SendQueueCfg) *SendQueue {
+	q := &SendQueue{
+		SendQueueCfg: cfg,
+		chats:        make(map[types.TgChatID]*chatSendQueue),
+		tokens:       make(chan struct{}),
+	}
+	q.debugLogger = q.debugLogger.Fork(q.debugLogger.Name() + ":sendQueue")
+	go q.tokensLoop()
+	return q
+}
+
+// Send schedules the message and waits for the result of sending. Messages
+// that are not bound to any chat (such as callback answers) are sent
+// immediately, keeping only the global rate limit.
+func (q *SendQueue) Send(msg tg.Chattable) (tg.Message, error) {
+	send := func() (tg.Message, error) { return q.send(msg) }
+
+	chatID, ok := chatIDOf(msg)
+	if !ok {
+		return q.sendWithRetries(&sendJob{send: send})
+	}
+
+	return q.SendRequest(chatID, send)
+}
+
+// SendRequest schedules the arbitrary request to the given chat. It is useful
+// for the requests that the Telegram library doesn't support directly.
+func (q *SendQueue) SendRequest(chatID types.TgChatID, send func() (tg.Message, error)) (tg.Message, error) {
+	job := &sendJob{send: send, result: make(chan sendResult, 1)}
+
+	q.lock.Lock()
+	cq, ok := q.chats[chatID]
+	if !ok {
+		cq = new(chatSendQueue)
+		q.chats[chatID] = cq
+		go q.chatLoop(chatID, cq)
+	}
+	job.chat = cq
+	cq.jobs = append(cq.jobs, job)
+	q.lock.Unlock()
+
+	select {
+	case res := <-job.result:
+		return res.msg, res.err
+	case <-q.closeChan:
+		return tg.Message{}, errSendQueueClosed
+	}
+}
+
+func (q *SendQueue) tokensLoop() {
+	q.debugLogger.Println("▶️ Starting send queue")
+	defer q.debugLogger.Println("⏹️ Stopping send queue")
+
+	ticker := time.NewTicker(q.globalInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case q.tokens <- struct{}{}:
+			// Token is taken, waiting for the next tick
+			select {
+			case <-ticker.C:
+			case <-q.closeChan:
+				return
+			}
+		case <-q.closeChan:
+			return
+		}
+	}
+}
+
+// chatLoop sends the queued messages of the one chat. It removes the chat
+// queue and exits when the queue is empty and the next message can be sent
+// without waiting.
+func (q *SendQueue) chatLoop(chatID types.TgChatID, cq *chatSendQueue) {
+	interval := q.chatInterval
+	if chatID < 0 {
+		interval = q.groupInterval
+	}
+
+	for {
+		q.lock.Lock()
+		readyAt := cq.lastSent.Add(interval)
+		if cq.pausedUntil.After(readyAt) {
+			readyAt = cq.pausedUntil
+		}
+		if len(cq.jobs) == 0 {
+			if !time.Now().Before(readyAt) {
+				delete(q.chats, chatID)
+				q.lock.Unlock()
+				return
+			}
+			q.lock.Unlock()
+			// Keep the queue until the interval passes, so the next message
+			// will wait for it
+			if !q.sleep(time.Until(readyAt)) {
+				return
+			}
+			continue
+		}
+		job := cq.jobs[0]
+		cq.jobs = cq.jobs[1:]
+		q.lock.Unlock()
+
+		if !q.sleep(time.Until(readyAt)) {
+			job.result <- sendResult{err: errSendQueueClosed}
+			continue
+		}
+
+		m, err := q.sendWithRetries(job)
+		job.result <- sendResult{m, err}
+
+		q.lock.Lock()
+		cq.lastSent = time.Now()
+		q.lock.Unlock()
+	}
+}
+
+func (q *SendQueue) sendWithRetries(job *sendJob) (tg.Message, error) {
+	retryInterval := q.retryInterval
+	for attempt := 0; ; attempt++ {
+		if !q.takeToken() {
+			return tg.Message{}, errSendQueueClosed
+		}
+
+		m, err := job.send()
+		var tgErr *tg.Error
+		if errors.As(err, &tgErr) && tgErr.RetryAfter > 0 {
+			// The other requests will hit the limit too, so hold them all
+			q.pause(job.chat, time.Now().Add(time.Duration(tgErr.RetryAfter)*time.Second))
+		}
+		if err == nil || attempt >= q.maxRetries {
+			return m, err
+		}
+
+		delay, ok := retryDelay(err, retryInterval)
+		if !ok {
+			return m, err
+		}
+
+		q.debugLogger.Printf("Cannot send message (%v), retrying in %v", err, delay)
+		if !q.sleep(delay) {
+			return m, err
+		}
+		retryInterval *= 2
+	}
+}
+
+// takeToken waits for the global rate limit and the global pause. It returns
+// false if the queue was closed while waiting.
+func (q *SendQueue) takeToken() bool {
+	for {
+		q.lock.Lock()
+		pausedUntil := q.pausedUntil
+		q.lock.Unlock()
+
+		if !q.sleep(time.Until(pausedUntil)) {
+			return false
+		}
+
+		select {
+		case <-q.tokens:
+		case <-q.closeChan:
+			return false
+		}
+
+		q.lock.Lock()
+		paused := time.Now().Before(q.pausedUntil)
+		q.lock.Unlock()
+		if !paused {
+			return true
+		}
+		// The pause began while we were waiting for the token
+	}
+}
+
+// pause holds the chat queue (or all the requests, if the chat is nil) until
+// the given time.
+func (q *SendQueue) pause(cq *chatSendQueue, until time.Time) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	pausedUntil := &q.pausedUntil
+	if cq != nil {
+		pausedUntil = &cq.pausedUntil
+	}
+	if until.After(*pausedUntil) {
+		*pausedUntil = until
+	}
+}
+
+// sleep waits for the given duration and returns false if the queue was closed
+// while waiting.
+func (q *SendQueue) sleep(d time.Duration) bool {
+	if d <= 0 {
+		return true
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-q.closeChan:
+		return false
+	}
+}
+
+// retryDelay returns the delay before the next attempt, or false if the error
+// is permanent.
+func retryDelay(err error, backoff time.Duration) (time.Duration, bool) {
+	var tgErr *tg.Error
+	if errors.As(err, &tgErr) {
+		if tgErr.RetryAfter > 0 {
+			return time.Duration(tgErr.RetryAfter) * time.Second, true
+		}
+		if tgErr.Code == http.StatusTooManyRequests || tgErr.Code >= http.StatusInternalServerError {
+			return backoff, true
+		}
+		return 0, false
+	}
+
+	// Network problems are transient
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return backoff, true
+	}
+
+	return 0, false
+}
+
+func chatIDOf(msg tg.Chattable) (types.TgChatID, bool) {
+	switch m := msg.(type) {
+	case tg.MessageConfig:
+		return m.ChatID, true
+	case *tg.MessageConfig:
+		return m.ChatID, true
+	case tg.EditMessageTextConfig:
+		return m.ChatID, true
+	case tg.EditMessageReplyMarkupConfig:
+		return m.ChatID, true
+	case tg.DeleteMessageConfig:
+		return m.ChatID, true
+	}
+	return 0, false
+}
@@ -0,0 +1,283 @@
+package app
+
+import (
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/FreeFeed/freefeed-tg-client/types"
+	"github.com/davidmz/debug-log"
+	tg "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSendQueue(t *testing.T, cfg SendQueueCfg) (*SendQueue, chan struct{}) {
+	closeChan := make(chan struct{})
+	cfg.closeChan = closeChan
+	cfg.debugLogger = debug.NewLogger("test")
+	if cfg.globalInterval == 0 {
+		cfg.globalInterval = time.Millisecond
+	}
+	if cfg.send == nil {
+		cfg.send = func(tg.Chattable) (tg.Message, error) { return tg.Message{}, nil }
+	}
+	q := NewSendQueue(cfg)
+	t.Cleanup(func() {
+		select {
+		case <-closeChan:
+		default:
+			close(closeChan)
+		}
+	})
+	return q, closeChan
+}
+
+// queueLength returns the number of the waiting jobs of the chat.
+func (q *SendQueue) queueLength(chatID types.TgChatID) int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if cq, ok := q.chats[chatID]; ok {
+		return len(cq.jobs)
+	}
+	return 0
+}
+
+func TestSendQueueChatOrder(t *testing.T) {
+	const chatID = 123
+	q, _ := newTestSendQueue(t, SendQueueCfg{chatInterval: time.Millisecond})
+
+	var (
+		lock  sync.Mutex
+		order []int
+	)
+	release := make(chan struct{})
+	request := func(n int) func() (tg.Message, error) {
+		return func() (tg.Message, error) {
+			if n == 0 {
+				// Hold the queue until all the other requests are added
+				<-release
+			}
+			lock.Lock()
+			order = append(order, n)
+			lock.Unlock()
+			return tg.Message{MessageID: n}, nil
+		}
+	}
+
+	var wg sync.WaitGroup
+	for n := 0; n < 5; n++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			msg, err := q.SendRequest(chatID, request(n))
+			assert.NoError(t, err)
+			assert.Equal(t, n, msg.MessageID)
+		}(n)
+		// Wait for the request to be taken or queued
+		require.Eventually(t, func() bool { return q.queueLength(chatID) == n }, time.Second, time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, order)
+}
+
+func TestSendQueueGlobalPacing(t *testing.T) {
+	const interval = 20 * time.Millisecond
+	var (
+		lock  sync.Mutex
+		times []time.Time
+	)
+	q, _ := newTestSendQueue(t, SendQueueCfg{
+		globalInterval: interval,
+		send: func(tg.Chattable) (tg.Message, error) {
+			lock.Lock()
+			times = append(times, time.Now())
+			lock.Unlock()
+			return tg.Message{}, nil
+		},
+	})
+
+	// Different chats don't wait for each other, only for the global tokens
+	var wg sync.WaitGroup
+	for chatID := int64(1); chatID <= 5; chatID++ {
+		wg.Add(1)
+		go func(chatID int64) {
+			defer wg.Done()
+			_, err := q.Send(tg.NewMessage(chatID, "test"))
+			assert.NoError(t, err)
+		}(chatID)
+	}
+	wg.Wait()
+
+	require.Len(t, times, 5)
+	first, last := times[0], times[0]
+	for _, tm := range times {
+		if tm.Before(first) {
+			first = tm
+		}
+		if tm.After(last) {
+			last = tm
+		}
+	}
+	// The first tick may be already buffered by the ticker
+	assert.GreaterOrEqual(t, last.Sub(first), 3*interval)
+}
+
+func TestSendQueueRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	q, _ := newTestSendQueue(t, SendQueueCfg{
+		maxRetries:    3,
+		retryInterval: time.Millisecond,
+		send: func(tg.Chattable) (tg.Message, error) {
+			if calls.Add(1) == 1 {
+				return tg.Message{}, &tg.Error{
+					Code:               http.StatusTooManyRequests,
+					ResponseParameters: tg.ResponseParameters{RetryAfter: 1},
+				}
+			}
+			return tg.Message{MessageID: 1}, nil
+		},
+	})
+
+	start := time.Now()
+	msg, err := q.Send(tg.NewMessage(123, "test"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, msg.MessageID)
+	assert.EqualValues(t, 2, calls.Load())
+	// The RetryAfter takes precedence over the retryInterval
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+}
+
+func TestSendQueueRetryAfterPause(t *testing.T) {
+	tooManyRequests := &tg.Error{
+		Code:               http.StatusTooManyRequests,
+		ResponseParameters: tg.ResponseParameters{RetryAfter: 1},
+	}
+
+	t.Run("chat", func(t *testing.T) {
+		var calls atomic.Int32
+		q, _ := newTestSendQueue(t, SendQueueCfg{
+			send: func(tg.Chattable) (tg.Message, error) {
+				if calls.Add(1) == 1 {
+					return tg.Message{}, tooManyRequests
+				}
+				return tg.Message{}, nil
+			},
+		})
+
+		start := time.Now()
+		_, err := q.Send(tg.NewMessage(123, "first"))
+		require.Error(t, err)
+
+		// The other chats are not paused
+		_, err = q.Send(tg.NewMessage(456, "test"))
+		require.NoError(t, err)
+		assert.Less(t, time.Since(start), time.Second)
+
+		_, err = q.Send(tg.NewMessage(123, "second"))
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), time.Second)
+	})
+
+	t.Run("global", func(t *testing.T) {
+		var calls atomic.Int32
+		q, _ := newTestSendQueue(t, SendQueueCfg{
+			send: func(tg.Chattable) (tg.Message, error) {
+				if calls.Add(1) == 1 {
+					return tg.Message{}, tooManyRequests
+				}
+				return tg.Message{}, nil
+			},
+		})
+
+		start := time.Now()
+		_, err := q.Send(tg.NewCallback("1", "first"))
+		require.Error(t, err)
+
+		_, err = q.Send(tg.NewMessage(123, "test"))
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), time.Second)
+	})
+}
+
+func TestSendQueueIdleChats(t *testing.T) {
+	const (
+		chatID   = 123
+		interval = 50 * time.Millisecond
+	)
+	q, _ := newTestSendQueue(t, SendQueueCfg{chatInterval: interval})
+	chatsCount := func() int {
+		q.lock.Lock()
+		defer q.lock.Unlock()
+		return len(q.chats)
+	}
+
+	start := time.Now()
+	_, err := q.Send(tg.NewMessage(chatID, "first"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, chatsCount())
+
+	// The chat queue is kept until the interval passes
+	_, err = q.Send(tg.NewMessage(chatID, "second"))
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), interval)
+
+	require.Eventually(t, func() bool { return chatsCount() == 0 }, time.Second, time.Millisecond)
+}
+
+func TestSendQueueMaxRetries(t *testing.T) {
+	var calls atomic.Int32
+	q, _ := newTestSendQueue(t, SendQueueCfg{
+		maxRetries:    2,
+		retryInterval: time.Millisecond,
+		send: func(tg.Chattable) (tg.Message, error) {
+			calls.Add(1)
+			return tg.Message{}, &tg.Error{Code: http.StatusBadGateway, Message: "Bad Gateway"}
+		},
+	})
+
+	_, err := q.Send(tg.NewMessage(123, "test"))
+	assert.Error(t, err)
+	assert.EqualValues(t, 3, calls.Load())
+
+	// Permanent errors are not retried
+	calls.Store(0)
+	q.send = func(tg.Chattable) (tg.Message, error) {
+		calls.Add(1)
+		return tg.Message{}, &tg.Error{Code: http.StatusBadRequest, Message: "Bad Request"}
+	}
+	_, err = q.Send(tg.NewMessage(123, "test"))
+	assert.Error(t, err)
+	assert.EqualValues(t, 1, calls.Load())
+}
+
+func TestSendQueueShutdown(t *testing.T) {
+	const chatID = 123
+	q, closeChan := newTestSendQueue(t, SendQueueCfg{chatInterval: time.Hour})
+
+	_, err := q.Send(tg.NewMessage(chatID, "first"))
+	require.NoError(t, err)
+
+	// The second message waits for the chatInterval
+	result := make(chan error, 1)
+	go func() {
+		_, err := q.Send(tg.NewMessage(chatID, "second"))
+		result <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	close(closeChan)
+
+	select {
+	case err := <-result:
+		assert.ErrorIs(t, err, errSendQueueClosed)
+	case <-time.After(time.Second):
+		t.Fatal("Send is not interrupted by shutdown")
+	}
+
+	_, err = q.Send(tg.NewMessage(chatID, "third"))
+	assert.ErrorIs(t, err, errSendQueueClosed)
+}
@@ -0,0 +1,23 @@
+package app
+
+import "time"
+
+// compactSentRecords removes the expired sent message records and the dead
+// entries of the records log of all chats.
+func (a *App) compactSentRecords() {
+	chatIDs, err := a.Store.ListIDs()
+	if err != nil {
+		a.ErrorLogger.Println("Cannot read chat IDs:", err)
+		return
+	}
+
+	now := time.Now()
+	for _, chatID := range chatIDs {
+		removed, err := a.Store.CompactMsgRecs(chatID, now)
+		if err != nil {
+			a.ErrorLogger.Printf("Cannot compact sent records of %d: %v", chatID, err)
+		} else if removed > 0 {
+			a.DebugLogger.Printf("Removed %d expired sent records of %d", removed, chatID)
+		}
+	}
+}
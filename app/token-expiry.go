@@ -0,0 +1,46 @@
+package app
+
+import (
+	"time"
+
+	"github.com/FreeFeed/freefeed-tg-client/chat"
+)
+
+func (a *App) checkTokensExpiry() {
+	chatIDs, err := a.Store.ListIDs()
+	if err != nil {
+		a.ErrorLogger.Println("Cannot read chat IDs:", err)
+		return
+	}
+
+	for _, chatID := range chatIDs {
+		state, err := a.Store.LoadState(chatID)
+		if err != nil {
+			a.ErrorLogger.Printf("Cannot load state of %d: %v", chatID, err)
+			continue
+		}
+		if !state.IsAuthorized() || !state.IsActive() || state.TokenRevoked || state.TokenExpiryWarned {
+			continue
+		}
+
+		ch, err := chat.New(chatID, a)
+		if err != nil {
+			a.ErrorLogger.Printf("Error initiating chat #%d: %v", chatID, err)
+			continue
+		}
+
+		if ch.State.TokenScopes == nil {
+			// Token metadata was never loaded (the chat was created by the
+			// older version of the bot)
+			if err := ch.UpdateTokenInfo(); err != nil {
+				a.ErrorLogger.Printf("Cannot load token info of %d: %v", chatID, err)
+				continue
+			}
+		}
+
+		if ch.TokenExpiresSoon(time.Now()) {
+			a.DebugLogger.Println("Access token expires soon, warning", chatID)
+			ch.WarnTokenExpiry()
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package app
+
+import (
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/FreeFeed/freefeed-tg-client/chat"
+	"github.com/FreeFeed/freefeed-tg-client/store"
+	"github.com/FreeFeed/freefeed-tg-client/types"
+)
+
+const (
+	// How often to look for the saved searches to run
+	watchesCheckInterval = time.Minute
+	// Average interval between the runs of the same saved search
+	watchPollInterval = 15 * time.Minute
+)
+
+// watchesLoop periodically runs the saved searches of all chats. The next run
+// time of every search is kept in the store, so the schedule survives
+// restarts.
+func (a *App) watchesLoop() {
+	a.DebugLogger.Println("▶️ Starting saved searches loop")
+	defer a.DebugLogger.Println("⏹️ Stopping saved searches loop")
+
+	ticker := time.NewTicker(watchesCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			a.runDueWatches()
+		case <-a.closeChan:
+			return
+		}
+	}
+}
+
+func (a *App) runDueWatches() {
+	chatIDs, err := a.Store.ListIDs()
+	if err != nil {
+		a.ErrorLogger.Println("Cannot read chat IDs:", err)
+		return
+	}
+
+	for _, chatID := range chatIDs {
+		watches, err := a.Store.ListWatches(chatID)
+		if err != nil {
+			a.ErrorLogger.Printf("Cannot load saved searches of %d: %v", chatID, err)
+			continue
+		}
+
+		for _, watch := range watches {
+			if time.Now().Before(watch.NextPollAt) {
+				continue
+			}
+
+			a.runWatch(chatID, watch)
+
+			select {
+			case <-a.closeChan:
+				return
+			default:
+			}
+		}
+	}
+}
+
+func (a *App) runWatch(chatID types.TgChatID, watch store.Watch) {
+	// Schedule the next run first, so the failing search doesn't run on
+	// every tick
+	if watch.NextPollAt.IsZero() {
+		// Newly created watch, spread the first runs over the interval
+		watch.NextPollAt = time.Now().Add(randomDuration(watchPollInterval))
+	} else {
+		// From 0.75 to 1.25 of the interval
+		watch.NextPollAt = time.Now().Add(watchPollInterval*3/4 + randomDuration(watchPollInterval/2))
+	}
+	defer func() {
+		// The watch may be deleted while running
+		err := a.Store.UpdateWatch(chatID, watch)
+		if err != nil && !errors.Is(err, store.ErrNotFound) {
+			a.ErrorLogger.Printf("Cannot save search of %d: %v", chatID, err)
+		}
+	}()
+
+	ch, err := chat.New(chatID, a)
+	if err != nil {
+		a.ErrorLogger.Printf("Cannot create chat %d: %v", chatID, err)
+		return
+	}
+	if !ch.State.IsAuthorized() || !ch.State.IsActive() || ch.State.TokenRevoked {
+		return
+	}
+
+	a.DebugLogger.Printf("Running saved search %q of %d", watch.Query, chatID)
+	if err := ch.CheckWatch(&watch); err != nil {
+		a.ErrorLogger.Printf("Cannot run saved search of %d: %v", chatID, err)
+	}
+}
+
+// randomDuration returns the random duration in [0, d).
+func randomDuration(d time.Duration) time.Duration {
+	return time.Duration(rand.Int63n(int64(d)))
+}
@@ -39,257 +39,616 @@ func init() {
 }
 
 var messageKeyToIndex = map[string]int{
-	":alien: Cannot load events: %v":                              25,
-	":alien: Unknown command":                                     30,
-	":alien: Unknown command %v":                                  21,
-	":alien: Unknown event: %v":                                   89,
-	":back: Back":                                                 5,
-	":bell: Subscribe to comments":                                9,
-	":broken_heart: Unlike":                                       6,
-	":cop: %s blocked %s in group %s":                             86,
-	":cop: %s has deleted your comment to the \"%s\":":            77,
-	":cop: %s has deleted your comment to the post in %s \"%s\":": 78,
-	":cop: %s has removed a comment from %s to the post in the group %s \"%s\":": 79,
-	":cop: %s has removed the post from %s from the group %s":                    82,
-	":cop: %s has removed the post from %s from the group %s \"%s\":":            83,
-	":cop: %s has removed your post from the group %s":                           80,
-	":cop: %s has removed your post from the group %s \"%s\":":                   81,
-	":cop: %s unblocked %s in group %s":                                          87,
-	":door: %s left the direct message \"%s\":":                                  56,
-	":e-mail: %s mentioned you in a comment to the post \"%s\":":                 46,
-	":e-mail: %s mentioned you in a comment to the post in %s \"%s\":":           47,
-	":e-mail: %s mentioned you in the post in %s:":                               45,
-	":e-mail: %s mentioned you in the post:":                                     44,
-	":e-mail: %s replied to you in a comment to the post \"%s\":":                48,
-	":e-mail: %s replied to you in a comment to the post in %s \"%s\":":          49,
-	":e-mail: New comment was posted by %s to the direct message \"%s\":":        58,
-	":e-mail: New comment was posted by %s to the post \"%s\":":                  59,
-	":e-mail: You received a direct message from %s:":                            57,
+	"%s wants to join %s":          184,
+	"%s wants to subscribe to you": 183,
+	"1 day":                        94,
+	"1 month":                      96,
+	"1 week":                       95,
+	":alarm_clock: I will remind you about this at %s.":              175,
+	":alarm_clock: Remind me…":                                       13,
+	":alarm_clock: Reminder:":                                        180,
+	":alarm_clock: Reminder: the post is not available anymore (%v)": 179,
+	":alien: Cannot load direct messages: %v":                        21,
+	":alien: Cannot load events: %v":                                 67,
+	":alien: Cannot load posts: %v":                                  207,
+	":alien: Cannot search: %v":                                      205,
+	":alien: Unknown command":                                        72,
+	":alien: Unknown command %v":                                     61,
+	":alien: Unknown event: %v":                                      167,
+	":arrow_down: Expand":                                            5,
+	":arrow_down: Next page":                                         201,
+	":arrow_up: Previous page":                                       200,
+	":back: Back":                                                    6,
+	":bell: Subscribe to comments":                                   10,
+	":bookmark: Save":                                                12,
+	":broken_heart: Unlike":                                          7,
+	":cop: %s blocked %s in group %s":                                164,
+	":cop: %s has deleted your comment to the \"%s\":":               155,
+	":cop: %s has deleted your comment to the post in %s \"%s\":":    156,
+	":cop: %s has removed a comment from %s to the post in the group %s \"%s\":": 157,
+	":cop: %s has removed the post from %s from the group %s":                    160,
+	":cop: %s has removed the post from %s from the group %s \"%s\":":            161,
+	":cop: %s has removed your post from the group %s":                           158,
+	":cop: %s has removed your post from the group %s \"%s\":":                   159,
+	":cop: %s unblocked %s in group %s":                                          165,
+	":cop: Done: %s":                                                             89,
+	":cop: Moderate…":                                                            15,
+	":door: %s left the direct message \"%s\":":                                  131,
+	":e-mail: %s mentioned you in a comment to the post \"%s\":":                 121,
+	":e-mail: %s mentioned you in a comment to the post in %s \"%s\":":           122,
+	":e-mail: %s mentioned you in the post in %s:":                               120,
+	":e-mail: %s mentioned you in the post:":                                     119,
+	":e-mail: %s replied to you in a comment to the post \"%s\":":                123,
+	":e-mail: %s replied to you in a comment to the post in %s \"%s\":":          124,
+	":e-mail: Direct message to %s is sent.":                                     110,
+	":e-mail: New comment was posted by %s to the direct message \"%s\":":        133,
+	":e-mail: New comment was posted by %s to the post \"%s\":":                  134,
+	":e-mail: You received a direct message from %s:":                            132,
+	":globe_with_meridians: Open #%d":                                            199,
 	":globe_with_meridians: Open comment":                                        1,
 	":globe_with_meridians: Open post":                                           0,
-	":heart: Like":                                                               7,
-	":key: Create token":                                                         40,
-	":link: %s mentioned your comment in the comment to post \"%s\":":            54,
-	":link: %s mentioned your comment in the post in %s:":                        51,
-	":link: %s mentioned your comment in the post:":                              50,
-	":link: %s mentioned your post in the comment to post \"%s\":":               55,
-	":link: %s mentioned your post in the post in %s:":                           53,
-	":link: %s mentioned your post in the post:":                                 52,
-	":minus: %s request to join %s was rejected by %s":                           75,
-	":minus: %s revoked admin privileges from %s in the group %s":                73,
-	":minus: %s revoked subscription request to %s":                              71,
-	":minus: %s revoked subscription request to you":                             70,
-	":minus: %s unsubscribed from %s":                                            69,
-	":minus: %s unsubscribed from your feed":                                     67,
-	":no_bell: Unsubscribe from comments":                                        8,
-	":no_entry_sign: Cancel":                                                     43,
-	":no_entry_sign: Your request to join group %s was rejected":                 65,
-	":no_entry_sign: Your subscription request to %s was rejected":               63,
-	":plus: %s promoted %s to admin in the group %s":                             72,
-	":plus: %s request to join %s was approved by %s":                            74,
-	":plus: %s subscribed to %s":                                                 68,
-	":plus: %s subscribed to your feed":                                          66,
-	":raising_hand: %s sent a request to join %s that you admin":                 61,
-	":raising_hand: %s sent you a subscription request":                          60,
-	":shrug: Unknown command":                                                    38,
-	":speech_balloon: @-Reply":                                                   3,
-	":speech_balloon: Comment more":                                              10,
-	":speech_balloon: Reply":                                                     2,
-	":tada: %s has joined FreeFeed using your invitation":                        88,
-	":tada: Comment successfully created!":                                       37,
-	":warning: Cannot load event data, probably this message is too old":         16,
-	":warning: Cannot load event: %v":                                            15,
-	":warning: Error: %v":                                                        20,
-	":warning: FreeFeed error: %v":                                               17,
-	":white_check_mark: Accept":                                                  11,
-	":white_check_mark: Accepted!":                                               18,
-	":white_check_mark: Your request to join group %s was approved":              64,
-	":white_check_mark: Your subscription request to %s was approved":            62,
-	":x: Reject":                            12,
-	":x: Rejected!":                         19,
-	"<welcome HTML>":                        14,
-	"Action is cancelled":                   22,
-	"Can not send a comment without a text": 35,
-	"Cannot load user information: %v":      28,
-	"Checking your token...":                32,
-	"Enter your comment text.":              41,
-	"Enter your comment text. The comment will be prefixed with \"%s\"": 42,
-	"Error creating comment: %v":                                        36,
-	"Group admin":                                                       84,
-	"Hello, @%s!\nIt's all set. Now when the bot sees the update on FreeFeed, it will show it to you.": 34,
-	"Language is %v now":                 13,
-	"Looks like this token isn't valid.": 31,
+	":heart: Like":                                                               8,
+	":hourglass: Your FreeFeed access token expires on %s. Please create a new token and send it to the bot, otherwise the bot will stop working.": 213,
+	":inbox_tray: Send new token": 214,
+	":key: Create new token":      112,
+	":key: Create token":          111,
+	":link: %s mentioned your comment in the comment to post \"%s\":":                     129,
+	":link: %s mentioned your comment in the post in %s:":                                 126,
+	":link: %s mentioned your comment in the post:":                                       125,
+	":link: %s mentioned your post in the comment to post \"%s\":":                        130,
+	":link: %s mentioned your post in the post in %s:":                                    128,
+	":link: %s mentioned your post in the post:":                                          127,
+	":mag: New post by %s matching \"%s\":":                                               137,
+	":mag: New post matching \"%s\":":                                                     136,
+	":mag: Search is saved. The bot will notify you about the new posts matching \"%s\".": 233,
+	":minus: %s request to join %s was rejected by %s":                                    153,
+	":minus: %s revoked admin privileges from %s in the group %s":                         151,
+	":minus: %s revoked subscription request to %s":                                       149,
+	":minus: %s revoked subscription request to you":                                      148,
+	":minus: %s unsubscribed from %s":                                                     147,
+	":minus: %s unsubscribed from your feed":                                              145,
+	":mute: Mute %s":                                                                      91,
+	":mute: Mute all":                                                                     227,
+	":mute: Mute group %s":                                                                92,
+	":mute: Mute this post":                                                               90,
+	":mute: Muted. Use /mutes to manage the muted items.":                                 97,
+	":mute: Mute…":                                                 14,
+	":new: New post by %s:":                                        135,
+	":no_bell: Unsubscribe from #%d":                               226,
+	":no_bell: Unsubscribe from comments":                          9,
+	":no_entry_sign: Cancel":                                       88,
+	":no_entry_sign: Cancel reminder":                              176,
+	":no_entry_sign: Your request to join group %s was rejected":   143,
+	":no_entry_sign: Your subscription request to %s was rejected": 141,
+	":page_facing_up: Post by %s:":                                 211,
+	":page_facing_up: Post:":                                       210,
+	":plus: %s promoted %s to admin in the group %s":               150,
+	":plus: %s request to join %s was approved by %s":              152,
+	":plus: %s subscribed to %s":                                   146,
+	":plus: %s subscribed to your feed":                            144,
+	":pushpin: Matched filters:":                                   29,
+	":raising_hand: %s sent a request to join %s that you admin":   139,
+	":raising_hand: %s sent you a subscription request":            138,
+	":shrug: Unknown command":                                      82,
+	":sound: Unmute #%d":                                           99,
+	":speech_balloon: @-Reply":                                     3,
+	":speech_balloon: Chat":                                        28,
+	":speech_balloon: Comment more":                                16,
+	":speech_balloon: Reply":                                       2,
+	":speech_balloon: You are chatting with %s in the direct message \"%s\". Everything you write will be posted as a comment. Use /endchat to finish.": 25,
+	":tada: %s has joined FreeFeed using your invitation":                166,
+	":tada: Comment successfully created!":                               79,
+	":warning: Cannot load event data, probably this message is too old": 54,
+	":warning: Cannot load event: %v":                                    53,
+	":warning: Cannot send the direct message: %v":                       109,
+	":warning: Confirm: %s":                                              87,
+	":warning: Error: %v":                                                58,
+	":warning: FreeFeed error: %v":                                       55,
+	":warning: FreeFeed has rejected your access token, probably it was revoked or expired. The bot will not show you updates until you send it a new token.": 212,
+	":warning: This action is not available":                          86,
+	":white_check_mark: Accept":                                       17,
+	":white_check_mark: Accept #%d":                                   185,
+	":white_check_mark: Accept all":                                   187,
+	":white_check_mark: Accept these %d":                              189,
+	":white_check_mark: Accepted!":                                    56,
+	":white_check_mark: Your request to join group %s was approved":   142,
+	":white_check_mark: Your subscription request to %s was approved": 140,
+	":x: Reject":                                  18,
+	":x: Reject #%d":                              186,
+	":x: Reject all":                              188,
+	":x: Reject these %d":                         190,
+	":x: Rejected!":                               57,
+	":x: Remove #%d":                              39,
+	":x: Unsave":                                  11,
+	"<welcome HTML>":                              52,
+	"Accepted!":                                   192,
+	"Action is cancelled":                         63,
+	"Block %s in %s":                              85,
+	"Can not send a comment without a text":       26,
+	"Can not send a message without a text":       81,
+	"Cannot cancel reminder: %v":                  178,
+	"Cannot create reminder: %v":                  173,
+	"Cannot find user @%s: %v":                    20,
+	"Cannot follow @%s: %v":                       44,
+	"Cannot load the filters: %v":                 37,
+	"Cannot load the muted items: %v":             98,
+	"Cannot load the saved posts: %v":             197,
+	"Cannot load the subscription requests: %v":   181,
+	"Cannot load the tracked posts: %v":           222,
+	"Cannot load user information: %v":            70,
+	"Cannot process %d of %d requests":            195,
+	"Cannot remove filter: %v":                    42,
+	"Cannot remove post from saved: %v":           196,
+	"Cannot remove saved search: %v":              237,
+	"Cannot unmute: %v":                           106,
+	"Cannot unsubscribe from %d posts":            230,
+	"Checking your token...":                      75,
+	"Custom time…":                                171,
+	"Delete comment":                              83,
+	"Done":                                        229,
+	"Enter the text of the direct message to %s.": 115,
+	"Enter your comment text.":                    117,
+	"Enter your comment text. The comment will be prefixed with \"%s\"": 118,
+	"Error creating comment: %v":                                        27,
+	"Filter is added: %s":                                               33,
+	"Filter is removed":                                                 41,
+	"Forever":                                                           93,
+	"Group admin":                                                       162,
+	"Hello, @%s!\nIt's all set. Now when the bot sees the update on FreeFeed, it will show it to you.": 78,
+	"In 1 hour":                          168,
+	"In 3 hours":                         169,
+	"Invalid filter: %v":                 31,
+	"Invalid recipient: %v":              107,
+	"Language is %v now":                 51,
+	"Looks like this token isn't valid.": 73,
 	"More…":                              4,
-	"OK, we will remove all of your data now. Use the /start command if you want to come back.":                              24,
-	"Please create the access token and send it to the bot:":                                                                 39,
-	"Something wrong happened: %v":                                                                                           33,
-	"We already know each other. Use the /logout command if you want to delete all of your data or start over.":              23,
-	"You are using this bot as %s. Use the /logout command if you want to delete all of your data or start as another user.": 29,
-	"Your updates are paused now.":                                                                                           26,
-	"Your updates are resumed now.":                                                                                          27,
-	"group admin":                                                                                                            76,
-	"you":                                                                                                                    85,
+	"Muted posts, users and groups:":     101,
+	"OK, we will remove all of your data now. Use the /start command if you want to come back.": 66,
+	"Only the latest %d saved posts are shown, see all of them on the site:":                    203,
+	"Only the user who enabled the topics mode can use these buttons":                           50,
+	"Only the user who enabled the topics mode or a chat administrator can change it.":          215,
+	"Pending subscription requests (%d):":                                                       191,
+	"Please create the access token and send it to the bot:":                                    113,
+	"Please send the delay like 30m, 2h or 1d12h.":                                              172,
+	"Please send the usernames of the recipients separated by spaces.":                          80,
+	"Post":                                  221,
+	"Post is not available":                 224,
+	"Post is removed from saved":            60,
+	"Post is saved, see /saved":             59,
+	"Rejected!":                             193,
+	"Reminder is cancelled":                 177,
+	"Remove post from %s":                   84,
+	"Saved search is removed":               236,
+	"Something wrong happened: %v":          76,
+	"The conversation with %s is finished.": 24,
+	"The requests have changed, please check the updated list":              194,
+	"The topics mode is available only in supergroups with topics enabled.": 216,
+	"The topics mode is off.": 218,
+	"The topics mode is on.":  219,
+	"The topics mode is on. The bot will create a topic for every post. Your messages in the topic will be posted as comments to the post. Make sure the bot is an admin with the right to manage topics.": 217,
+	"There are no posts here.":                   208,
+	"This search is outdated, please repeat it.": 62,
+	"This token doesn't have the permissions the bot needs: %s. Please create a new token with these permissions.": 77,
+	"This token has already expired. Please create a new one.":                                                     74,
+	"Tomorrow":                          170,
+	"Unmuted":                           105,
+	"Usage: /chat @username":            19,
+	"Usage: /direct @username text":     108,
+	"Usage: /feed [@username or group]": 206,
+	"Usage: /filter add [only|never|highlight] regex":     30,
+	"Usage: /follow @username or /follow group":           43,
+	"Usage: /search query":                                204,
+	"Usage: /watch query":                                 231,
+	"Use \"/topics on\" or \"/topics off\" to change it.": 220,
+	"Want to see more posts?":                             209,
+	"We already know each other. Use the /logout command if you want to delete all of your data or start over.":              65,
+	"Welcome back! The bot will show you FreeFeed updates again.":                                                            64,
+	"When should I remind you? Send the delay, for example 30m, 2h or 1d12h.":                                                116,
+	"Who should receive the direct message? Send the usernames separated by spaces.":                                         114,
+	"You are following @%s now. New posts will be shown here.":                                                               45,
+	"You are not in the conversation mode.":                                                                                  23,
+	"You are using this bot as %s. Use the /logout command if you want to delete all of your data or start as another user.": 71,
+	"You cannot have more than %d filters. Use /filter to remove some of them.":                                              32,
+	"You cannot have more than %d pending reminders.":                                                                        174,
+	"You cannot have more than %d saved searches. Use /watches to remove some of them.":                                      232,
+	"You don't follow @%s anymore.":                                                                                          46,
+	"You don't follow @%s.":                                                                                                  47,
+	"You don't follow anyone yet. Use /follow @username or /follow group.":                                                   48,
+	"You don't get all comments of any post.":                                                                                223,
+	"You follow: %s. Use /unfollow to stop.":                                                                                 49,
+	"You get all comments of these posts:":                                                                                   228,
+	"You have no filters. Use \"/filter add regex\" to add one.":                                                             38,
+	"You have no muted posts, users or groups.":                                                                              100,
+	"You have no pending subscription requests.":                                                                             182,
+	"You have no recent direct messages with %s.":                                                                            22,
+	"You have no saved posts. Use the \"Save\" button to save one.":                                                          198,
+	"You have no saved searches. Use /watch to add one.":                                                                     234,
+	"Your filters:":                        40,
+	"Your saved posts (%d):":               202,
+	"Your saved searches (tap to remove):": 235,
+	"Your updates are paused now.":         68,
+	"Your updates are resumed now.":        69,
+	"group %s":                             103,
+	"group admin":                          154,
+	"highlight if matches /%s/":            35,
+	"last activity %s":                     225,
+	"never notify if matches /%s/":         36,
+	"only notify if matches /%s/":          34,
+	"post \"%s\"":                          102,
+	"until %s":                             104,
+	"you":                                  163,
 }
 
-var enIndex = []uint32{ // 91 elements
+var enIndex = []uint32{ // 239 elements
 	// Entry 0 - 1F
 	0x00000000, 0x00000021, 0x00000045, 0x0000005c,
-	0x00000075, 0x0000007d, 0x00000089, 0x0000009f,
-	0x000000ac, 0x000000d0, 0x000000ed, 0x0000010b,
-	0x00000125, 0x00000130, 0x00000146, 0x000002cb,
-	0x000002ee, 0x00000331, 0x00000351, 0x0000036e,
-	0x0000037c, 0x00000393, 0x000003b1, 0x000003c5,
-	0x0000042f, 0x00000489, 0x000004ab, 0x000004c8,
-	0x000004e6, 0x0000050a, 0x00000584, 0x0000059c,
+	0x00000075, 0x0000007d, 0x00000091, 0x0000009d,
+	0x000000b3, 0x000000c0, 0x000000e4, 0x00000101,
+	0x0000010c, 0x0000011c, 0x00000137, 0x00000146,
+	0x00000158, 0x00000176, 0x00000190, 0x0000019b,
+	0x000001b2, 0x000001d1, 0x000001fc, 0x0000022b,
+	0x00000251, 0x0000027a, 0x00000310, 0x00000336,
+	0x00000354, 0x0000036a, 0x00000385, 0x000003b5,
 	// Entry 20 - 3F
-	0x000005bf, 0x000005d6, 0x000005f6, 0x00000659,
-	0x0000067f, 0x0000069d, 0x000006c2, 0x000006da,
-	0x00000711, 0x00000724, 0x0000073d, 0x00000780,
-	0x00000797, 0x000007c1, 0x000007f4, 0x00000833,
-	0x0000087b, 0x000008bb, 0x00000904, 0x00000935,
-	0x0000096f, 0x0000099d, 0x000009d4, 0x00000a18,
-	0x00000a59, 0x00000a87, 0x00000aba, 0x00000b02,
-	0x00000b40, 0x00000b75, 0x00000bb6, 0x00000bf9,
+	0x000003cb, 0x00000418, 0x0000042f, 0x0000044e,
+	0x0000046b, 0x0000048b, 0x000004aa, 0x000004e3,
+	0x000004f5, 0x00000503, 0x00000515, 0x00000531,
+	0x0000055b, 0x00000577, 0x000005b3, 0x000005d4,
+	0x000005ed, 0x00000632, 0x0000065c, 0x0000069c,
+	0x000006b2, 0x00000837, 0x0000085a, 0x0000089d,
+	0x000008bd, 0x000008da, 0x000008e8, 0x000008ff,
+	0x00000919, 0x00000934, 0x00000952, 0x0000097d,
 	// Entry 40 - 5F
-	0x00000c39, 0x00000c7a, 0x00000cb8, 0x00000cdd,
-	0x00000d07, 0x00000d28, 0x00000d4e, 0x00000d80,
-	0x00000db4, 0x00000dec, 0x00000e31, 0x00000e6a,
-	0x00000ea4, 0x00000eb0, 0x00000ee5, 0x00000f28,
-	0x00000f7d, 0x00000fb4, 0x00000ff4, 0x00001035,
-	0x0000107f, 0x0000108b, 0x0000108f, 0x000010b8,
-	0x000010e3, 0x0000111a, 0x00001137,
-} // Size: 388 bytes
+	0x00000991, 0x000009cd, 0x00000a37, 0x00000a91,
+	0x00000ab3, 0x00000ad0, 0x00000aee, 0x00000b12,
+	0x00000b8c, 0x00000ba4, 0x00000bc7, 0x00000c00,
+	0x00000c17, 0x00000c37, 0x00000ca7, 0x00000d0a,
+	0x00000d2f, 0x00000d70, 0x00000d96, 0x00000dae,
+	0x00000dbd, 0x00000dd4, 0x00000de9, 0x00000e10,
+	0x00000e29, 0x00000e40, 0x00000e52, 0x00000e68,
+	0x00000e7a, 0x00000e92, 0x00000e9a, 0x00000ea0,
+	// Entry 60 - 7F
+	0x00000ea7, 0x00000eaf, 0x00000ee3, 0x00000f06,
+	0x00000f1c, 0x00000f46, 0x00000f65, 0x00000f72,
+	0x00000f7e, 0x00000f8a, 0x00000f92, 0x00000fa7,
+	0x00000fc0, 0x00000fde, 0x0000100e, 0x00001038,
+	0x0000104b, 0x00001062, 0x00001099, 0x000010e8,
+	0x00001117, 0x0000115f, 0x00001178, 0x000011bb,
+	0x000011e5, 0x00001218, 0x00001257, 0x0000129f,
+	0x000012df, 0x00001328, 0x00001359, 0x00001393,
+	// Entry 80 - 9F
+	0x000013c1, 0x000013f8, 0x0000143c, 0x0000147d,
+	0x000014ab, 0x000014de, 0x00001526, 0x00001564,
+	0x0000157d, 0x0000159e, 0x000015c8, 0x000015fd,
+	0x0000163e, 0x00001681, 0x000016c1, 0x00001702,
+	0x00001740, 0x00001765, 0x0000178f, 0x000017b0,
+	0x000017d6, 0x00001808, 0x0000183c, 0x00001874,
+	0x000018b9, 0x000018f2, 0x0000192c, 0x00001938,
+	0x0000196d, 0x000019b0, 0x00001a05, 0x00001a3c,
+	// Entry A0 - BF
+	0x00001a7c, 0x00001abd, 0x00001b07, 0x00001b13,
+	0x00001b17, 0x00001b40, 0x00001b6b, 0x00001ba2,
+	0x00001bbf, 0x00001bc9, 0x00001bd4, 0x00001bdd,
+	0x00001bec, 0x00001c19, 0x00001c37, 0x00001c6a,
+	0x00001c9f, 0x00001cbf, 0x00001cd5, 0x00001cf3,
+	0x00001d35, 0x00001d4d, 0x00001d7a, 0x00001da5,
+	0x00001dc5, 0x00001ddf, 0x00001e00, 0x00001e12,
+	0x00001e30, 0x00001e3f, 0x00001e65, 0x00001e7c,
+	// Entry C0 - DF
+	0x00001ea3, 0x00001ead, 0x00001eb7, 0x00001ef0,
+	0x00001f17, 0x00001f3c, 0x00001f5f, 0x00001f9b,
+	0x00001fbe, 0x00001fd7, 0x00001fee, 0x00002008,
+	0x00002052, 0x00002067, 0x00002084, 0x000020a6,
+	0x000020c7, 0x000020e0, 0x000020f8, 0x0000210f,
+	0x0000212f, 0x000021c7, 0x00002257, 0x00002273,
+	0x000022c4, 0x0000230a, 0x000023cf, 0x000023e7,
+	0x000023fe, 0x0000242e, 0x00002433, 0x00002458,
+	// Entry E0 - FF
+	0x00002480, 0x00002496, 0x000024aa, 0x000024cc,
+	0x000024dc, 0x00002501, 0x00002506, 0x0000252a,
+	0x0000253e, 0x00002593, 0x000025e8, 0x0000261b,
+	0x00002640, 0x00002658, 0x0000267a,
+} // Size: 980 bytes
 
-const enData string = "" + // Size: 4407 bytes
+const enData string = "" + // Size: 9850 bytes
 	"\x02:globe_with_meridians: Open post\x02:globe_with_meridians: Open comm" +
-	"ent\x02:speech_balloon: Reply\x02:speech_balloon: @-Reply\x02More…\x02:b" +
-	"ack: Back\x02:broken_heart: Unlike\x02:heart: Like\x02:no_bell: Unsubscr" +
-	"ibe from comments\x02:bell: Subscribe to comments\x02:speech_balloon: Co" +
-	"mment more\x02:white_check_mark: Accept\x02:x: Reject\x02Language is %[1" +
-	"]v now\x02Hello again! This bot will help you keep up-to-date with every" +
-	"thing happening on FreeFeed. It will send you <a href=\x22https://freefe" +
-	"ed.net/filter/notifications\x22>FreeFeed notifications</a> and you can r" +
-	"eply to them directly in Telegram.\x0a\x0aTo give the bot access to your" +
-	" notifications, you need to create a special access token. Please create" +
-	" it using the button below and send it to the bot:\x02:warning: Cannot l" +
-	"oad event: %[1]v\x02:warning: Cannot load event data, probably this mess" +
-	"age is too old\x02:warning: FreeFeed error: %[1]v\x02:white_check_mark: " +
-	"Accepted!\x02:x: Rejected!\x02:warning: Error: %[1]v\x02:alien: Unknown " +
-	"command %[1]v\x02Action is cancelled\x02We already know each other. Use " +
-	"the /logout command if you want to delete all of your data or start over" +
-	".\x02OK, we will remove all of your data now. Use the /start command if " +
-	"you want to come back.\x02:alien: Cannot load events: %[1]v\x02Your upda" +
-	"tes are paused now.\x02Your updates are resumed now.\x02Cannot load user" +
-	" information: %[1]v\x02You are using this bot as %[1]s. Use the /logout " +
-	"command if you want to delete all of your data or start as another user." +
-	"\x02:alien: Unknown command\x02Looks like this token isn't valid.\x02Che" +
-	"cking your token...\x02Something wrong happened: %[1]v\x02Hello, @%[1]s!" +
-	"\x0aIt's all set. Now when the bot sees the update on FreeFeed, it will " +
-	"show it to you.\x02Can not send a comment without a text\x02Error creati" +
-	"ng comment: %[1]v\x02:tada: Comment successfully created!\x02:shrug: Unk" +
-	"nown command\x02Please create the access token and send it to the bot:" +
-	"\x02:key: Create token\x02Enter your comment text.\x02Enter your comment" +
-	" text. The comment will be prefixed with \x22%[1]s\x22\x02:no_entry_sign" +
-	": Cancel\x02:e-mail: %[1]s mentioned you in the post:\x02:e-mail: %[1]s " +
-	"mentioned you in the post in %[2]s:\x02:e-mail: %[1]s mentioned you in a" +
-	" comment to the post \x22%[2]s\x22:\x02:e-mail: %[1]s mentioned you in a" +
-	" comment to the post in %[2]s \x22%[3]s\x22:\x02:e-mail: %[1]s replied t" +
-	"o you in a comment to the post \x22%[2]s\x22:\x02:e-mail: %[1]s replied " +
-	"to you in a comment to the post in %[2]s \x22%[3]s\x22:\x02:link: %[1]s " +
-	"mentioned your comment in the post:\x02:link: %[1]s mentioned your comme" +
-	"nt in the post in %[2]s:\x02:link: %[1]s mentioned your post in the post" +
-	":\x02:link: %[1]s mentioned your post in the post in %[2]s:\x02:link: %[" +
-	"1]s mentioned your comment in the comment to post \x22%[2]s\x22:\x02:lin" +
-	"k: %[1]s mentioned your post in the comment to post \x22%[2]s\x22:\x02:d" +
-	"oor: %[1]s left the direct message \x22%[2]s\x22:\x02:e-mail: You receiv" +
-	"ed a direct message from %[1]s:\x02:e-mail: New comment was posted by %[" +
-	"1]s to the direct message \x22%[2]s\x22:\x02:e-mail: New comment was pos" +
-	"ted by %[1]s to the post \x22%[2]s\x22:\x02:raising_hand: %[1]s sent you" +
-	" a subscription request\x02:raising_hand: %[1]s sent a request to join %" +
-	"[2]s that you admin\x02:white_check_mark: Your subscription request to %" +
-	"[1]s was approved\x02:no_entry_sign: Your subscription request to %[1]s " +
-	"was rejected\x02:white_check_mark: Your request to join group %[1]s was " +
-	"approved\x02:no_entry_sign: Your request to join group %[1]s was rejecte" +
-	"d\x02:plus: %[1]s subscribed to your feed\x02:minus: %[1]s unsubscribed " +
-	"from your feed\x02:plus: %[1]s subscribed to %[2]s\x02:minus: %[1]s unsu" +
-	"bscribed from %[2]s\x02:minus: %[1]s revoked subscription request to you" +
-	"\x02:minus: %[1]s revoked subscription request to %[2]s\x02:plus: %[1]s " +
-	"promoted %[2]s to admin in the group %[3]s\x02:minus: %[1]s revoked admi" +
-	"n privileges from %[2]s in the group %[3]s\x02:plus: %[1]s request to jo" +
-	"in %[2]s was approved by %[3]s\x02:minus: %[1]s request to join %[2]s wa" +
-	"s rejected by %[3]s\x02group admin\x02:cop: %[1]s has deleted your comme" +
-	"nt to the \x22%[2]s\x22:\x02:cop: %[1]s has deleted your comment to the " +
-	"post in %[2]s \x22%[3]s\x22:\x02:cop: %[1]s has removed a comment from %" +
-	"[2]s to the post in the group %[3]s \x22%[4]s\x22:\x02:cop: %[1]s has re" +
-	"moved your post from the group %[2]s\x02:cop: %[1]s has removed your pos" +
-	"t from the group %[2]s \x22%[3]s\x22:\x02:cop: %[1]s has removed the pos" +
-	"t from %[2]s from the group %[3]s\x02:cop: %[1]s has removed the post fr" +
-	"om %[2]s from the group %[3]s \x22%[4]s\x22:\x02Group admin\x02you\x02:c" +
-	"op: %[1]s blocked %[2]s in group %[3]s\x02:cop: %[1]s unblocked %[2]s in" +
-	" group %[3]s\x02:tada: %[1]s has joined FreeFeed using your invitation" +
-	"\x02:alien: Unknown event: %[1]v"
+	"ent\x02:speech_balloon: Reply\x02:speech_balloon: @-Reply\x02More…\x02:a" +
+	"rrow_down: Expand\x02:back: Back\x02:broken_heart: Unlike\x02:heart: Lik" +
+	"e\x02:no_bell: Unsubscribe from comments\x02:bell: Subscribe to comments" +
+	"\x02:x: Unsave\x02:bookmark: Save\x02:alarm_clock: Remind me…\x02:mute: " +
+	"Mute…\x02:cop: Moderate…\x02:speech_balloon: Comment more\x02:white_chec" +
+	"k_mark: Accept\x02:x: Reject\x02Usage: /chat @username\x02Cannot find us" +
+	"er @%[1]s: %[2]v\x02:alien: Cannot load direct messages: %[1]v\x02You ha" +
+	"ve no recent direct messages with %[1]s.\x02You are not in the conversat" +
+	"ion mode.\x02The conversation with %[1]s is finished.\x02:speech_balloon" +
+	": You are chatting with %[1]s in the direct message \x22%[2]s\x22. Every" +
+	"thing you write will be posted as a comment. Use /endchat to finish.\x02" +
+	"Can not send a comment without a text\x02Error creating comment: %[1]v" +
+	"\x02:speech_balloon: Chat\x02:pushpin: Matched filters:\x02Usage: /filte" +
+	"r add [only|never|highlight] regex\x02Invalid filter: %[1]v\x02You canno" +
+	"t have more than %[1]d filters. Use /filter to remove some of them.\x02F" +
+	"ilter is added: %[1]s\x02only notify if matches /%[1]s/\x02highlight if " +
+	"matches /%[1]s/\x02never notify if matches /%[1]s/\x02Cannot load the fi" +
+	"lters: %[1]v\x02You have no filters. Use \x22/filter add regex\x22 to ad" +
+	"d one.\x02:x: Remove #%[1]d\x02Your filters:\x02Filter is removed\x02Can" +
+	"not remove filter: %[1]v\x02Usage: /follow @username or /follow group" +
+	"\x02Cannot follow @%[1]s: %[2]v\x02You are following @%[1]s now. New pos" +
+	"ts will be shown here.\x02You don't follow @%[1]s anymore.\x02You don't " +
+	"follow @%[1]s.\x02You don't follow anyone yet. Use /follow @username or " +
+	"/follow group.\x02You follow: %[1]s. Use /unfollow to stop.\x02Only the " +
+	"user who enabled the topics mode can use these buttons\x02Language is %[" +
+	"1]v now\x02Hello again! This bot will help you keep up-to-date with ever" +
+	"ything happening on FreeFeed. It will send you <a href=\x22https://freef" +
+	"eed.net/filter/notifications\x22>FreeFeed notifications</a> and you can " +
+	"reply to them directly in Telegram.\x0a\x0aTo give the bot access to you" +
+	"r notifications, you need to create a special access token. Please creat" +
+	"e it using the button below and send it to the bot:\x02:warning: Cannot " +
+	"load event: %[1]v\x02:warning: Cannot load event data, probably this mes" +
+	"sage is too old\x02:warning: FreeFeed error: %[1]v\x02:white_check_mark:" +
+	" Accepted!\x02:x: Rejected!\x02:warning: Error: %[1]v\x02Post is saved, " +
+	"see /saved\x02Post is removed from saved\x02:alien: Unknown command %[1]" +
+	"v\x02This search is outdated, please repeat it.\x02Action is cancelled" +
+	"\x02Welcome back! The bot will show you FreeFeed updates again.\x02We al" +
+	"ready know each other. Use the /logout command if you want to delete all" +
+	" of your data or start over.\x02OK, we will remove all of your data now." +
+	" Use the /start command if you want to come back.\x02:alien: Cannot load" +
+	" events: %[1]v\x02Your updates are paused now.\x02Your updates are resum" +
+	"ed now.\x02Cannot load user information: %[1]v\x02You are using this bot" +
+	" as %[1]s. Use the /logout command if you want to delete all of your dat" +
+	"a or start as another user.\x02:alien: Unknown command\x02Looks like thi" +
+	"s token isn't valid.\x02This token has already expired. Please create a " +
+	"new one.\x02Checking your token...\x02Something wrong happened: %[1]v" +
+	"\x02This token doesn't have the permissions the bot needs: %[1]s. Please" +
+	" create a new token with these permissions.\x02Hello, @%[1]s!\x0aIt's al" +
+	"l set. Now when the bot sees the update on FreeFeed, it will show it to " +
+	"you.\x02:tada: Comment successfully created!\x02Please send the username" +
+	"s of the recipients separated by spaces.\x02Can not send a message witho" +
+	"ut a text\x02:shrug: Unknown command\x02Delete comment\x02Remove post fr" +
+	"om %[1]s\x02Block %[1]s in %[2]s\x02:warning: This action is not availab" +
+	"le\x02:warning: Confirm: %[1]s\x02:no_entry_sign: Cancel\x02:cop: Done: " +
+	"%[1]s\x02:mute: Mute this post\x02:mute: Mute %[1]s\x02:mute: Mute group" +
+	" %[1]s\x02Forever\x021 day\x021 week\x021 month\x02:mute: Muted. Use /mu" +
+	"tes to manage the muted items.\x02Cannot load the muted items: %[1]v\x02" +
+	":sound: Unmute #%[1]d\x02You have no muted posts, users or groups.\x02Mu" +
+	"ted posts, users and groups:\x02post \x22%[1]s\x22\x02group %[1]s\x02unt" +
+	"il %[1]s\x02Unmuted\x02Cannot unmute: %[1]v\x02Invalid recipient: %[1]v" +
+	"\x02Usage: /direct @username text\x02:warning: Cannot send the direct me" +
+	"ssage: %[1]v\x02:e-mail: Direct message to %[1]s is sent.\x02:key: Creat" +
+	"e token\x02:key: Create new token\x02Please create the access token and " +
+	"send it to the bot:\x02Who should receive the direct message? Send the u" +
+	"sernames separated by spaces.\x02Enter the text of the direct message to" +
+	" %[1]s.\x02When should I remind you? Send the delay, for example 30m, 2h" +
+	" or 1d12h.\x02Enter your comment text.\x02Enter your comment text. The c" +
+	"omment will be prefixed with \x22%[1]s\x22\x02:e-mail: %[1]s mentioned y" +
+	"ou in the post:\x02:e-mail: %[1]s mentioned you in the post in %[2]s:" +
+	"\x02:e-mail: %[1]s mentioned you in a comment to the post \x22%[2]s\x22:" +
+	"\x02:e-mail: %[1]s mentioned you in a comment to the post in %[2]s \x22%" +
+	"[3]s\x22:\x02:e-mail: %[1]s replied to you in a comment to the post \x22" +
+	"%[2]s\x22:\x02:e-mail: %[1]s replied to you in a comment to the post in " +
+	"%[2]s \x22%[3]s\x22:\x02:link: %[1]s mentioned your comment in the post:" +
+	"\x02:link: %[1]s mentioned your comment in the post in %[2]s:\x02:link: " +
+	"%[1]s mentioned your post in the post:\x02:link: %[1]s mentioned your po" +
+	"st in the post in %[2]s:\x02:link: %[1]s mentioned your comment in the c" +
+	"omment to post \x22%[2]s\x22:\x02:link: %[1]s mentioned your post in the" +
+	" comment to post \x22%[2]s\x22:\x02:door: %[1]s left the direct message " +
+	"\x22%[2]s\x22:\x02:e-mail: You received a direct message from %[1]s:\x02" +
+	":e-mail: New comment was posted by %[1]s to the direct message \x22%[2]s" +
+	"\x22:\x02:e-mail: New comment was posted by %[1]s to the post \x22%[2]s" +
+	"\x22:\x02:new: New post by %[1]s:\x02:mag: New post matching \x22%[1]s" +
+	"\x22:\x02:mag: New post by %[1]s matching \x22%[2]s\x22:\x02:raising_han" +
+	"d: %[1]s sent you a subscription request\x02:raising_hand: %[1]s sent a " +
+	"request to join %[2]s that you admin\x02:white_check_mark: Your subscrip" +
+	"tion request to %[1]s was approved\x02:no_entry_sign: Your subscription " +
+	"request to %[1]s was rejected\x02:white_check_mark: Your request to join" +
+	" group %[1]s was approved\x02:no_entry_sign: Your request to join group " +
+	"%[1]s was rejected\x02:plus: %[1]s subscribed to your feed\x02:minus: %[" +
+	"1]s unsubscribed from your feed\x02:plus: %[1]s subscribed to %[2]s\x02:" +
+	"minus: %[1]s unsubscribed from %[2]s\x02:minus: %[1]s revoked subscripti" +
+	"on request to you\x02:minus: %[1]s revoked subscription request to %[2]s" +
+	"\x02:plus: %[1]s promoted %[2]s to admin in the group %[3]s\x02:minus: %" +
+	"[1]s revoked admin privileges from %[2]s in the group %[3]s\x02:plus: %[" +
+	"1]s request to join %[2]s was approved by %[3]s\x02:minus: %[1]s request" +
+	" to join %[2]s was rejected by %[3]s\x02group admin\x02:cop: %[1]s has d" +
+	"eleted your comment to the \x22%[2]s\x22:\x02:cop: %[1]s has deleted you" +
+	"r comment to the post in %[2]s \x22%[3]s\x22:\x02:cop: %[1]s has removed" +
+	" a comment from %[2]s to the post in the group %[3]s \x22%[4]s\x22:\x02:" +
+	"cop: %[1]s has removed your post from the group %[2]s\x02:cop: %[1]s has" +
+	" removed your post from the group %[2]s \x22%[3]s\x22:\x02:cop: %[1]s ha" +
+	"s removed the post from %[2]s from the group %[3]s\x02:cop: %[1]s has re" +
+	"moved the post from %[2]s from the group %[3]s \x22%[4]s\x22:\x02Group a" +
+	"dmin\x02you\x02:cop: %[1]s blocked %[2]s in group %[3]s\x02:cop: %[1]s u" +
+	"nblocked %[2]s in group %[3]s\x02:tada: %[1]s has joined FreeFeed using " +
+	"your invitation\x02:alien: Unknown event: %[1]v\x02In 1 hour\x02In 3 hou" +
+	"rs\x02Tomorrow\x02Custom time…\x02Please send the delay like 30m, 2h or " +
+	"1d12h.\x02Cannot create reminder: %[1]v\x02You cannot have more than %[1" +
+	"]d pending reminders.\x02:alarm_clock: I will remind you about this at %" +
+	"[1]s.\x02:no_entry_sign: Cancel reminder\x02Reminder is cancelled\x02Can" +
+	"not cancel reminder: %[1]v\x02:alarm_clock: Reminder: the post is not av" +
+	"ailable anymore (%[1]v)\x02:alarm_clock: Reminder:\x02Cannot load the su" +
+	"bscription requests: %[1]v\x02You have no pending subscription requests." +
+	"\x02%[1]s wants to subscribe to you\x02%[1]s wants to join %[2]s\x02:whi" +
+	"te_check_mark: Accept #%[1]d\x02:x: Reject #%[1]d\x02:white_check_mark: " +
+	"Accept all\x02:x: Reject all\x02:white_check_mark: Accept these %[1]d" +
+	"\x02:x: Reject these %[1]d\x02Pending subscription requests (%[1]d):\x02" +
+	"Accepted!\x02Rejected!\x02The requests have changed, please check the up" +
+	"dated list\x02Cannot process %[1]d of %[2]d requests\x02Cannot remove po" +
+	"st from saved: %[1]v\x02Cannot load the saved posts: %[1]v\x02You have n" +
+	"o saved posts. Use the \x22Save\x22 button to save one.\x02:globe_with_m" +
+	"eridians: Open #%[1]d\x02:arrow_up: Previous page\x02:arrow_down: Next p" +
+	"age\x02Your saved posts (%[1]d):\x02Only the latest %[1]d saved posts ar" +
+	"e shown, see all of them on the site:\x02Usage: /search query\x02:alien:" +
+	" Cannot search: %[1]v\x02Usage: /feed [@username or group]\x02:alien: Ca" +
+	"nnot load posts: %[1]v\x02There are no posts here.\x02Want to see more p" +
+	"osts?\x02:page_facing_up: Post:\x02:page_facing_up: Post by %[1]s:\x02:w" +
+	"arning: FreeFeed has rejected your access token, probably it was revoked" +
+	" or expired. The bot will not show you updates until you send it a new t" +
+	"oken.\x02:hourglass: Your FreeFeed access token expires on %[1]s. Please" +
+	" create a new token and send it to the bot, otherwise the bot will stop " +
+	"working.\x02:inbox_tray: Send new token\x02Only the user who enabled the" +
+	" topics mode or a chat administrator can change it.\x02The topics mode i" +
+	"s available only in supergroups with topics enabled.\x02The topics mode " +
+	"is on. The bot will create a topic for every post. Your messages in the " +
+	"topic will be posted as comments to the post. Make sure the bot is an ad" +
+	"min with the right to manage topics.\x02The topics mode is off.\x02The t" +
+	"opics mode is on.\x02Use \x22/topics on\x22 or \x22/topics off\x22 to ch" +
+	"ange it.\x02Post\x02Cannot load the tracked posts: %[1]v\x02You don't ge" +
+	"t all comments of any post.\x02Post is not available\x02last activity %[" +
+	"1]s\x02:no_bell: Unsubscribe from #%[1]d\x02:mute: Mute all\x02You get a" +
+	"ll comments of these posts:\x02Done\x02Cannot unsubscribe from %[1]d pos" +
+	"ts\x02Usage: /watch query\x02You cannot have more than %[1]d saved searc" +
+	"hes. Use /watches to remove some of them.\x02:mag: Search is saved. The " +
+	"bot will notify you about the new posts matching \x22%[1]s\x22.\x02You h" +
+	"ave no saved searches. Use /watch to add one.\x02Your saved searches (ta" +
+	"p to remove):\x02Saved search is removed\x02Cannot remove saved search: " +
+	"%[1]v"
 
-var ruIndex = []uint32{ // 91 elements
+var ruIndex = []uint32{ // 239 elements
 	// Entry 0 - 1F
 	0x00000000, 0x0000002f, 0x0000006c, 0x0000008e,
-	0x000000b2, 0x000000bc, 0x000000ce, 0x000000eb,
-	0x000000fc, 0x00000133, 0x00000167, 0x00000190,
-	0x000001b4, 0x000001c9, 0x000001ec, 0x00000479,
-	0x000004b7, 0x0000052f, 0x00000556, 0x00000579,
-	0x0000058f, 0x000005ad, 0x000005e1, 0x00000603,
-	0x000006af, 0x00000734, 0x00000779, 0x000007ab,
-	0x000007e4, 0x00000825, 0x00000905, 0x00000933,
+	0x000000b2, 0x000000bc, 0x000000de, 0x000000f0,
+	0x0000010d, 0x0000011e, 0x00000155, 0x00000189,
+	0x000001b6, 0x000001d4, 0x000001f8, 0x00000215,
+	0x00000231, 0x0000025a, 0x0000027e, 0x00000293,
+	0x000002bf, 0x00000305, 0x0000035b, 0x000003a7,
+	0x000003d5, 0x00000405, 0x0000051a, 0x00000563,
+	0x000005a4, 0x000005c8, 0x000005f5, 0x0000065c,
 	// Entry 20 - 3F
-	0x00000975, 0x0000099d, 0x000009c7, 0x00000a75,
-	0x00000abe, 0x00000aff, 0x00000b2b, 0x00000b59,
-	0x00000bbf, 0x00000bdf, 0x00000c1e, 0x00000c9f,
-	0x00000cbc, 0x00000cf4, 0x00000d42, 0x00000d9c,
-	0x00000e0c, 0x00000e57, 0x00000eb8, 0x00000f04,
-	0x00000f66, 0x00000fa4, 0x00000ff8, 0x00001066,
-	0x000010c6, 0x00001113, 0x0000115e, 0x000011b0,
-	0x000011ed, 0x0000122a, 0x00001281, 0x000012d7,
+	0x00000681, 0x00000723, 0x00000748, 0x00000792,
+	0x000007cb, 0x0000080d, 0x0000084a, 0x000008da,
+	0x000008f4, 0x0000090d, 0x00000927, 0x0000095e,
+	0x000009a8, 0x000009e6, 0x00000a52, 0x00000a85,
+	0x00000aab, 0x00000b25, 0x00000b87, 0x00000bfc,
+	0x00000c1f, 0x00000eac, 0x00000eea, 0x00000f62,
+	0x00000f89, 0x00000fac, 0x00000fc2, 0x00000fe0,
+	0x00001008, 0x00001038, 0x0000106c, 0x000010c1,
 	// Entry 40 - 5F
-	0x0000132b, 0x00001392, 0x000013fa, 0x00001430,
-	0x0000146c, 0x000014ae, 0x000014df, 0x0000151f,
-	0x00001579, 0x000015cf, 0x0000163e, 0x0000169d,
-	0x000016ff, 0x0000172b, 0x0000177c, 0x000017e3,
-	0x00001849, 0x0000188f, 0x000018e1, 0x00001934,
-	0x00001990, 0x000019b8, 0x000019bf, 0x00001a00,
-	0x00001a43, 0x00001ace, 0x00001b0a,
-} // Size: 388 bytes
+	0x000010e3, 0x00001158, 0x00001204, 0x00001289,
+	0x000012ce, 0x00001300, 0x00001339, 0x0000137a,
+	0x0000145a, 0x00001488, 0x000014ca, 0x00001542,
+	0x0000156a, 0x00001594, 0x00001643, 0x000016f1,
+	0x0000171d, 0x00001781, 0x000017c8, 0x000017f6,
+	0x0000181c, 0x0000183d, 0x00001867, 0x0000189e,
+	0x000018c6, 0x000018e3, 0x000018fd, 0x00001929,
+	0x00001949, 0x00001976, 0x00001987, 0x00001992,
+	// Entry 60 - 7F
+	0x000019a1, 0x000019ae, 0x00001a13, 0x00001a69,
+	0x00001a87, 0x00001aeb, 0x00001b38, 0x00001b4b,
+	0x00001b5e, 0x00001b69, 0x00001b85, 0x00001bbc,
+	0x00001be9, 0x00001c22, 0x00001c7a, 0x00001cc6,
+	0x00001ce6, 0x00001d11, 0x00001d77, 0x00001e06,
+	0x00001e50, 0x00001ebb, 0x00001efa, 0x00001f7b,
+	0x00001fb3, 0x00002001, 0x0000205b, 0x000020cb,
+	0x00002116, 0x00002177, 0x000021c3, 0x00002225,
+	// Entry 80 - 9F
+	0x00002263, 0x000022b7, 0x00002325, 0x00002385,
+	0x000023d2, 0x0000241d, 0x0000246f, 0x000024ac,
+	0x000024d2, 0x0000250b, 0x0000254f, 0x0000258c,
+	0x000025e3, 0x00002639, 0x0000268d, 0x000026f4,
+	0x0000275c, 0x00002792, 0x000027ce, 0x00002810,
+	0x00002841, 0x00002881, 0x000028db, 0x00002931,
+	0x000029a0, 0x000029ff, 0x00002a61, 0x00002a8d,
+	0x00002ade, 0x00002b45, 0x00002bab, 0x00002bf1,
+	// Entry A0 - BF
+	0x00002c43, 0x00002c96, 0x00002cf2, 0x00002d1a,
+	0x00002d21, 0x00002d62, 0x00002da5, 0x00002e30,
+	0x00002e6c, 0x00002e7e, 0x00002e94, 0x00002ea1,
+	0x00002ebc, 0x00002f18, 0x00002f59, 0x00002fba,
+	0x00002ff9, 0x00003031, 0x00003059, 0x0000309c,
+	0x000030f6, 0x0000311c, 0x0000316f, 0x000031bb,
+	0x000031ef, 0x0000321a, 0x00003243, 0x00003261,
+	0x0000328a, 0x000032a8, 0x000032d7, 0x000032fb,
+	// Entry C0 - DF
+	0x0000333c, 0x0000334c, 0x00003360, 0x000033bc,
+	0x00003407, 0x00003454, 0x000034a4, 0x0000353e,
+	0x0000356b, 0x0000359c, 0x000035cd, 0x00003601,
+	0x00003685, 0x000036b6, 0x000036f7, 0x00003739,
+	0x0000377a, 0x0000379a, 0x000037be, 0x000037d9,
+	0x000037ff, 0x00003910, 0x00003a1d, 0x00003a53,
+	0x00003ae0, 0x00003b54, 0x00003cc4, 0x00003ce8,
+	0x00003d0a, 0x00003d6c, 0x00003d75, 0x00003dc9,
+	// Entry E0 - FF
+	0x00003e25, 0x00003e43, 0x00003e71, 0x00003e9e,
+	0x00003ec8, 0x00003f18, 0x00003f25, 0x00003f7b,
+	0x00003fab, 0x00004026, 0x000040ad, 0x0000411b,
+	0x00004175, 0x000041a4, 0x000041f0,
+} // Size: 980 bytes
 
-const ruData string = "" + // Size: 6922 bytes
+const ruData string = "" + // Size: 16880 bytes
 	"\x02:globe_with_meridians: Открыть пост\x02:globe_with_meridians: Открыт" +
 	"ь комментарий\x02:speech_balloon: Ответить\x02:speech_balloon: @-Ответи" +
-	"ть\x02Ещё…\x02:back: Назад\x02:broken_heart: Не лайк\x02:heart: Лайк" +
-	"\x02:no_bell: Отписаться от комментов\x02:bell: Подписаться на комменты" +
-	"\x02:speech_balloon: Написать ещё\x02:white_check_mark: Одобрить\x02:x: " +
-	"Отказать\x02Ваш язык теперь %[1]v\x02Привет ещё раз! Этот бот поможет в" +
-	"ам быть в курсе всего, что происходит во FreeFeed-е. Он будет присылать" +
-	" вам <a href=\x22https://freefeed.net/filter/notifications\x22>нотификац" +
-	"ии</a>, и вы сможете отвечать на них прямо в Телеграме.\x0a\x0aДля того" +
-	" чтобы дать боту доступ к ваши нотификациям, вам нужно создать специальн" +
-	"ый токен доступа. Пожалуйста, создайте его с помощью кнопки ниже и отпр" +
-	"авьте боту:\x02:warning: Ошибка загрузки события: %[1]v\x02:warning: Не" +
-	" могу найти данные, возможно это сообщение слишком старое\x02:warning: О" +
-	"шибка FreeFeed: %[1]v\x02:white_check_mark: Принято!\x02:x: Отказано!" +
-	"\x02:warning: Ошибка: %[1]v\x02:alien: Неизвестная команда %[1]v\x02Дейс" +
-	"твие отменено\x02Мы с вами уже знакомы:) Используйте команду /logout чт" +
-	"обы удалить все свои данные и начать заново.\x02Ваши данные удаляются. " +
-	"Используйте команду /start если захотите вернуться.\x02:alien: Не удало" +
-	"сь загрузить события: %[1]v\x02Обновления приостановлены\x02Обновления " +
-	"снова доставляются\x02Не удалось получить информацию: %[1]v\x02Вы автор" +
-	"изованы как %[1]s. Используйте команду /logout чтобы удалить все свои д" +
-	"анные или начать работу как другой пользователь.\x02:alien: Неизвестная" +
-	" команда\x02Похоже что этот токен неправильный.\x02Проверяем ваш токен.." +
-	".\x02Что-то пошло не так: %[1]v\x02Привет, @%[1]s!\x0aВсё готово. Теперь" +
-	", когда бот увидит обновления на FreeFeed-е, он пришлёт вам сообщение." +
-	"\x02Не могу создать комментарий без текста.\x02Не удалось создать коммен" +
-	"тарий: %[1]v\x02:tada: Комментарий создан!\x02:shrug: Неизвестная коман" +
-	"да\x02Пожалуйста, создайте токен доступа и сообщите его боту:\x02:key: " +
-	"Создать токен\x02Введите текст вашего комментария:\x02Введите текст ваш" +
-	"его комментария. Комментарий будет начинаться с \x22%[1]s\x22\x02:no_en" +
-	"try_sign: Отмена\x02:e-mail: Вас упомянули в посте %[1]s:\x02:e-mail: Ва" +
+	"ть\x02Ещё…\x02:arrow_down: Развернуть\x02:back: Назад\x02:broken_heart:" +
+	" Не лайк\x02:heart: Лайк\x02:no_bell: Отписаться от комментов\x02:bell: " +
+	"Подписаться на комменты\x02:x: Убрать из сохранённых\x02:bookmark: Сохр" +
+	"анить\x02:alarm_clock: Напомнить…\x02:mute: Заглушить…\x02:cop: Модерац" +
+	"ия…\x02:speech_balloon: Написать ещё\x02:white_check_mark: Одобрить\x02" +
+	":x: Отказать\x02Использование: /chat @username\x02Не удалось найти польз" +
+	"ователя @%[1]s: %[2]v\x02:alien: Не удалось загрузить личные сообщения:" +
+	" %[1]v\x02У вас нет недавних личных сообщений с %[1]s.\x02Вы не в режиме" +
+	" переписки.\x02Переписка с %[1]s завершена.\x02:speech_balloon: Вы переп" +
+	"исываетесь с %[1]s в личном сообщении «%[2]s». Всё, что вы напишете, бу" +
+	"дет опубликовано как комментарий. Используйте /endchat, чтобы закончить" +
+	".\x02Не могу создать комментарий без текста.\x02Не удалось создать комме" +
+	"нтарий: %[1]v\x02:speech_balloon: Переписка\x02:pushpin: Сработали филь" +
+	"тры:\x02Использование: /filter add [only|never|highlight] регулярное_вы" +
+	"ражение\x02Неверный фильтр: %[1]v\x02У вас не может быть больше %[1]d ф" +
+	"ильтров. Используйте /filter, чтобы удалить некоторые из них.\x02Фильтр" +
+	" добавлен: %[1]s\x02уведомлять, только если совпадает с /%[1]s/\x02выдел" +
+	"ять, если совпадает с /%[1]s/\x02не уведомлять, если совпадает с /%[1]s" +
+	"/\x02Не удалось загрузить фильтры: %[1]v\x02У вас нет фильтров. Использу" +
+	"йте «/filter add регулярное_выражение», чтобы добавить.\x02:x: Удалить " +
+	"#%[1]d\x02Ваши фильтры:\x02Фильтр удалён\x02Не удалось удалить фильтр: %" +
+	"[1]v\x02Использование: /follow @username или /follow группа\x02Не удалос" +
+	"ь подписаться на @%[1]s: %[2]v\x02Теперь вы следите за @%[1]s. Новые по" +
+	"сты будут показаны здесь.\x02Вы больше не следите за @%[1]s.\x02Вы не с" +
+	"ледите за @%[1]s.\x02Вы пока ни за кем не следите. Используйте /follow " +
+	"@username или /follow группа.\x02Вы следите за: %[1]s. Используйте /unfo" +
+	"llow, чтобы перестать.\x02Эти кнопки может использовать только тот, кто " +
+	"включил режим тем\x02Ваш язык теперь %[1]v\x02Привет ещё раз! Этот бот " +
+	"поможет вам быть в курсе всего, что происходит во FreeFeed-е. Он будет " +
+	"присылать вам <a href=\x22https://freefeed.net/filter/notifications\x22" +
+	">нотификации</a>, и вы сможете отвечать на них прямо в Телеграме.\x0a" +
+	"\x0aДля того чтобы дать боту доступ к ваши нотификациям, вам нужно созда" +
+	"ть специальный токен доступа. Пожалуйста, создайте его с помощью кнопки" +
+	" ниже и отправьте боту:\x02:warning: Ошибка загрузки события: %[1]v\x02:" +
+	"warning: Не могу найти данные, возможно это сообщение слишком старое\x02" +
+	":warning: Ошибка FreeFeed: %[1]v\x02:white_check_mark: Принято!\x02:x: О" +
+	"тказано!\x02:warning: Ошибка: %[1]v\x02Пост сохранён, см. /saved\x02Пос" +
+	"т убран из сохранённых\x02:alien: Неизвестная команда %[1]v\x02Этот пои" +
+	"ск устарел, пожалуйста, повторите его.\x02Действие отменено\x02С возвра" +
+	"щением! Бот снова будет показывать вам обновления FreeFeed.\x02Мы с вам" +
+	"и уже знакомы:) Используйте команду /logout чтобы удалить все свои данн" +
+	"ые и начать заново.\x02Ваши данные удаляются. Используйте команду /star" +
+	"t если захотите вернуться.\x02:alien: Не удалось загрузить события: %[1]" +
+	"v\x02Обновления приостановлены\x02Обновления снова доставляются\x02Не уд" +
+	"алось получить информацию: %[1]v\x02Вы авторизованы как %[1]s. Использу" +
+	"йте команду /logout чтобы удалить все свои данные или начать работу как" +
+	" другой пользователь.\x02:alien: Неизвестная команда\x02Похоже что этот " +
+	"токен неправильный.\x02Срок действия этого токена уже истёк. Пожалуйста" +
+	", создайте новый.\x02Проверяем ваш токен...\x02Что-то пошло не так: %[1]" +
+	"v\x02У этого токена нет прав, необходимых боту: %[1]s. Пожалуйста, созда" +
+	"йте новый токен с этими правами.\x02Привет, @%[1]s!\x0aВсё готово. Тепе" +
+	"рь, когда бот увидит обновления на FreeFeed-е, он пришлёт вам сообщение" +
+	".\x02:tada: Комментарий создан!\x02Пожалуйста, отправьте имена получател" +
+	"ей через пробел.\x02Нельзя отправить сообщение без текста\x02:shrug: Не" +
+	"известная команда\x02Удалить комментарий\x02Убрать пост из %[1]s\x02Заб" +
+	"локировать %[1]s в %[2]s\x02:warning: Это действие недоступно\x02:warni" +
+	"ng: Подтвердить: %[1]s\x02:no_entry_sign: Отмена\x02:cop: Готово: %[1]s" +
+	"\x02:mute: Заглушить этот пост\x02:mute: Заглушить %[1]s\x02:mute: Заглу" +
+	"шить группу %[1]s\x02Навсегда\x021 день\x021 неделя\x021 месяц\x02:mute" +
+	": Заглушено. Используйте /mutes для управления списком.\x02Не удалось за" +
+	"грузить заглушенные элементы: %[1]v\x02:sound: Вернуть #%[1]d\x02У вас " +
+	"нет заглушенных постов, пользователей или групп.\x02Заглушенные посты, " +
+	"пользователи и группы:\x02пост «%[1]s»\x02группа %[1]s\x02до %[1]s\x02З" +
+	"аглушка снята\x02Не удалось снять заглушку: %[1]v\x02Неверный получател" +
+	"ь: %[1]v\x02Использование: /direct @username текст\x02:warning: Не удал" +
+	"ось отправить личное сообщение: %[1]v\x02:e-mail: Личное сообщение для " +
+	"%[1]s отправлено.\x02:key: Создать токен\x02:key: Создать новый токен" +
+	"\x02Пожалуйста, создайте токен доступа и сообщите его боту:\x02Кому отпр" +
+	"авить личное сообщение? Отправьте имена пользователей через пробел.\x02" +
+	"Введите текст личного сообщения для %[1]s.\x02Когда напомнить? Отправьт" +
+	"е задержку, например 30m, 2h или 1d12h.\x02Введите текст вашего коммент" +
+	"ария:\x02Введите текст вашего комментария. Комментарий будет начинаться" +
+	" с \x22%[1]s\x22\x02:e-mail: Вас упомянули в посте %[1]s:\x02:e-mail: Ва" +
 	"с упомянули в посте %[1]s в группе %[2]s:\x02:e-mail: Вас упомянули в к" +
 	"омментарии %[1]s к посту \x22%[2]s\x22:\x02:e-mail: Вас упомянули в ком" +
 	"ментарии %[1]s к посту в группе %[2]s \x22%[3]s\x22:\x02:e-mail: Ответ " +
@@ -303,29 +662,76 @@ const ruData string = "" + // Size: 6922 bytes
 	"[1]s больше не участвует в директе \x22%[2]s\x22:\x02:e-mail: Вы получил" +
 	"и директ-сообщение от %[1]s:\x02:e-mail: Комментарий %[1]s к директ-соо" +
 	"бщению \x22%[2]s\x22:\x02:e-mail: Комментарий %[1]s к посту \x22%[2]s" +
-	"\x22:\x02:raising_hand: Запрос на подписку от %[1]s\x02:raising_hand: За" +
-	"прос на вступление в группу %[2]s от %[1]s\x02:white_check_mark: Ваш за" +
-	"прос на подписку к %[1]s одобрен!\x02:no_entry_sign: Ваш запрос на подп" +
-	"иску к %[1]s отклонён\x02:white_check_mark: Ваш запрос на вступление в " +
-	"группу %[1]s одобрен!\x02:white_check_mark: Ваш запрос на вступление в " +
-	"группу %[1]s отклонён\x02:plus: У вас новый подписчик: %[1]s\x02:minus:" +
-	" %[1]s больше не ваш подписчик:(\x02:plus: В группе %[2]s новый подписчи" +
-	"к: %[1]s\x02:minus: %[1]s вышел из группы %[2]s\x02:minus: Запрос подпи" +
-	"ски от %[1]s отозван\x02:minus: Запрос %[1]s на вступление в группу %[2" +
-	"]s отозван\x02:plus: %[1]s сделал(а) %[2]s администратором группы %[3]s" +
-	"\x02:minus: %[1]s отозвал(а) полномочия администратора группы %[3]s у %[" +
-	"2]s\x02:plus: Запрос %[1]s на вступление в группу %[2]s одобрен %[3]s" +
-	"\x02:minus: Запрос %[1]s на вступление в группу %[2]s отклонён %[3]s\x02" +
-	"администратором группы\x02:cop: Ваш комментарий был удалён %[1]s. Пост " +
-	"\x22%[2]s\x22:\x02:cop: Ваш комментарий в группе %[2]s был удалён %[1]s." +
-	" Пост \x22%[3]s\x22:\x02:cop: Комментарий %[2]s был удалён %[1]s. Пост в" +
-	" группе %[3]s \x22%[4]s\x22:\x02:cop: Ваш пост в группе %[2]s был удалён" +
-	" %[1]s\x02:cop: Ваш пост был удалён из группы %[2]s %[1]s. \x22%[3]s\x22" +
-	":\x02:cop: Модератор %[1]s удалил пост %[2]s из группы %[3]s\x02:cop: Мо" +
-	"дератор %[1]s удалил пост %[2]s из группы %[3]s \x22%[4]s\x22:\x02Админ" +
-	"истратор группы\x02вас\x02:cop: %[1]s заблокировал %[2]s в группе %[3]s" +
-	"\x02:cop: %[1]s разблокировал %[2]s в группе %[3]s\x02:tada: По вашему п" +
-	"риглашению зарегистрировался новый пользователь FreeFeed — %[1]s!\x02:a" +
-	"lien: Неизвестный тип события: %[1]v"
+	"\x22:\x02:new: Новый пост от %[1]s:\x02:mag: Новый пост по запросу «%[1]" +
+	"s»:\x02:mag: Новый пост от %[1]s по запросу «%[2]s»:\x02:raising_hand: З" +
+	"апрос на подписку от %[1]s\x02:raising_hand: Запрос на вступление в гру" +
+	"ппу %[2]s от %[1]s\x02:white_check_mark: Ваш запрос на подписку к %[1]s" +
+	" одобрен!\x02:no_entry_sign: Ваш запрос на подписку к %[1]s отклонён\x02" +
+	":white_check_mark: Ваш запрос на вступление в группу %[1]s одобрен!\x02:" +
+	"white_check_mark: Ваш запрос на вступление в группу %[1]s отклонён\x02:p" +
+	"lus: У вас новый подписчик: %[1]s\x02:minus: %[1]s больше не ваш подписч" +
+	"ик:(\x02:plus: В группе %[2]s новый подписчик: %[1]s\x02:minus: %[1]s в" +
+	"ышел из группы %[2]s\x02:minus: Запрос подписки от %[1]s отозван\x02:mi" +
+	"nus: Запрос %[1]s на вступление в группу %[2]s отозван\x02:plus: %[1]s с" +
+	"делал(а) %[2]s администратором группы %[3]s\x02:minus: %[1]s отозвал(а)" +
+	" полномочия администратора группы %[3]s у %[2]s\x02:plus: Запрос %[1]s н" +
+	"а вступление в группу %[2]s одобрен %[3]s\x02:minus: Запрос %[1]s на вс" +
+	"тупление в группу %[2]s отклонён %[3]s\x02администратором группы\x02:co" +
+	"p: Ваш комментарий был удалён %[1]s. Пост \x22%[2]s\x22:\x02:cop: Ваш ко" +
+	"мментарий в группе %[2]s был удалён %[1]s. Пост \x22%[3]s\x22:\x02:cop:" +
+	" Комментарий %[2]s был удалён %[1]s. Пост в группе %[3]s \x22%[4]s\x22:" +
+	"\x02:cop: Ваш пост в группе %[2]s был удалён %[1]s\x02:cop: Ваш пост был" +
+	" удалён из группы %[2]s %[1]s. \x22%[3]s\x22:\x02:cop: Модератор %[1]s у" +
+	"далил пост %[2]s из группы %[3]s\x02:cop: Модератор %[1]s удалил пост %" +
+	"[2]s из группы %[3]s \x22%[4]s\x22:\x02Администратор группы\x02вас\x02:c" +
+	"op: %[1]s заблокировал %[2]s в группе %[3]s\x02:cop: %[1]s разблокировал" +
+	" %[2]s в группе %[3]s\x02:tada: По вашему приглашению зарегистрировался " +
+	"новый пользователь FreeFeed — %[1]s!\x02:alien: Неизвестный тип события" +
+	": %[1]v\x02Через час\x02Через 3 часа\x02Завтра\x02Другое время…\x02Пожал" +
+	"уйста, отправьте задержку в виде 30m, 2h или 1d12h.\x02Не удалось созда" +
+	"ть напоминание: %[1]v\x02У вас не может быть больше %[1]d ожидающих нап" +
+	"оминаний.\x02:alarm_clock: Я напомню вам об этом в %[1]s.\x02:no_entry_" +
+	"sign: Отменить напоминание\x02Напоминание отменено\x02Не удалось отменит" +
+	"ь напоминание: %[1]v\x02:alarm_clock: Напоминание: пост больше не досту" +
+	"пен (%[1]v)\x02:alarm_clock: Напоминание:\x02Не удалось загрузить запро" +
+	"сы на подписку: %[1]v\x02У вас нет ожидающих запросов на подписку.\x02%" +
+	"[1]s хочет подписаться на вас\x02%[1]s хочет вступить в %[2]s\x02:white_" +
+	"check_mark: Принять #%[1]d\x02:x: Отклонить #%[1]d\x02:white_check_mark:" +
+	" Принять все\x02:x: Отклонить все\x02:white_check_mark: Принять эти %[1]" +
+	"d\x02:x: Отклонить эти %[1]d\x02Ожидающие запросы на подписку (%[1]d):" +
+	"\x02Принято!\x02Отклонено!\x02Запросы изменились, проверьте обновлённый " +
+	"список\x02Не удалось обработать %[1]d из %[2]d запросов\x02Не удалось у" +
+	"брать пост из сохранённых: %[1]v\x02Не удалось загрузить сохранённые по" +
+	"сты: %[1]v\x02У вас нет сохранённых постов. Используйте кнопку «Сохрани" +
+	"ть», чтобы сохранить пост.\x02:globe_with_meridians: Открыть #%[1]d\x02" +
+	":arrow_up: Предыдущая страница\x02:arrow_down: Следующая страница\x02Ваш" +
+	"и сохранённые посты (%[1]d):\x02Показаны только последние %[1]d сохранё" +
+	"нных постов, все они есть на сайте:\x02Использование: /search запрос" +
+	"\x02:alien: Не удалось выполнить поиск: %[1]v\x02Использование: /feed [@" +
+	"username или группа]\x02:alien: Не удалось загрузить посты: %[1]v\x02Зде" +
+	"сь нет постов.\x02Показать ещё посты?\x02:page_facing_up: Пост:\x02:pag" +
+	"e_facing_up: Пост от %[1]s:\x02:warning: FreeFeed отклонил ваш токен дос" +
+	"тупа, вероятно, он был отозван или истёк. Бот не будет показывать вам о" +
+	"бновления, пока вы не пришлёте ему новый токен.\x02:hourglass: Срок дей" +
+	"ствия вашего токена доступа FreeFeed истекает %[1]s. Пожалуйста, создай" +
+	"те новый токен и отправьте его боту, иначе бот перестанет работать.\x02" +
+	":inbox_tray: Отправить новый токен\x02Изменить режим тем может только то" +
+	"т, кто его включил, или администратор чата.\x02Режим тем доступен тольк" +
+	"о в супергруппах с включёнными темами.\x02Режим тем включён. Бот будет " +
+	"создавать отдельную тему для каждого поста. Ваши сообщения в теме будут" +
+	" опубликованы как комментарии к посту. Убедитесь, что бот — администрато" +
+	"р с правом управлять темами.\x02Режим тем выключен.\x02Режим тем включё" +
+	"н.\x02Используйте «/topics on» или «/topics off», чтобы изменить его." +
+	"\x02Пост\x02Не удалось загрузить отслеживаемые посты: %[1]v\x02Вы не пол" +
+	"учаете все комментарии ни к одному посту.\x02Пост недоступен\x02последн" +
+	"яя активность %[1]s\x02:no_bell: Отписаться от №%[1]d\x02:mute: Отписат" +
+	"ься от всех\x02Вы получаете все комментарии к этим постам:\x02Готово" +
+	"\x02Не удалось отписаться от некоторых постов (%[1]d)\x02Использование: " +
+	"/watch запрос\x02Нельзя сохранить больше %[1]d поисков. Удалите лишние с" +
+	" помощью /watches.\x02:mag: Поиск сохранён. Бот будет сообщать вам о нов" +
+	"ых постах по запросу «%[1]s».\x02У вас нет сохранённых поисков. Добавьт" +
+	"е поиск с помощью /watch.\x02Ваши сохранённые поиски (нажмите, чтобы уд" +
+	"алить):\x02Сохранённый поиск удалён\x02Не удалось удалить сохранённый п" +
+	"оиск: %[1]v"
 
-	// Total table size 12105 bytes (11KiB); checksum: 9DAE3B4C
+	// Total table size 28690 bytes (28KiB); checksum: F3E238C
@@ -0,0 +1,49 @@
+package chat
+
+import (
+	"fmt"
+
+	"github.com/FreeFeed/freefeed-tg-client/store"
+	"github.com/bluele/gcache"
+	tg "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// fakeApp records the actions of the chat. The methods not used by the tests
+// are not implemented and panic.
+type fakeApp struct {
+	App
+	sent    []tg.Chattable
+	saved   []*store.State
+	deleted bool
+	admins  map[int64]bool
+	msgRecs map[int]store.SentMsgRec
+	// The sync of saved posts is enabled when the saves is not nil
+	saves      gcache.Cache
+	localSaves []store.SavedPost
+}
+
+func (a *fakeApp) Send(msg tg.Chattable) (tg.Message, error) {
+	a.sent = append(a.sent, msg)
+	return tg.Message{}, nil
+}
+
+func (a *fakeApp) SaveState(state *store.State) error {
+	a.saved = append(a.saved, state)
+	return nil
+}
+
+func (a *fakeApp) GetMsgRec(_ ID, messageID int) (store.SentMsgRec, error) {
+	rec, ok := a.msgRecs[messageID]
+	if !ok {
+		return rec, fmt.Errorf("cannot find event data for this message: %w", store.ErrNotFound)
+	}
+	return rec, nil
+}
+
+func (a *fakeApp) RemoteSavesCache() gcache.Cache               { return a.saves }
+func (a *fakeApp) SyncSavesEnabled() bool                       { return a.saves != nil }
+func (a *fakeApp) ListSavedPosts(ID) ([]store.SavedPost, error) { return a.localSaves, nil }
+func (a *fakeApp) IsChatAdmin(_ ID, userID int64) (bool, error) { return a.admins[userID], nil }
+func (a *fakeApp) DeleteState(ID) error                         { a.deleted = true; return nil }
+func (a *fakeApp) StopRealtime(ID)                              {}
+func (a *fakeApp) Linkify(s string) string                      { return s }
@@ -45,7 +45,23 @@ func (c *Chat) postButtons(event *frf.Event) tg.InlineKeyboardMarkup {
 		),
 	}
 
-	return tg.NewInlineKeyboardMarkup(row)
+	markup := tg.NewInlineKeyboardMarkup(row)
+	if event.Type == "direct" && event.CreatedUser != nil {
+		markup.InlineKeyboard = append(markup.InlineKeyboard, []tg.InlineKeyboardButton{c.directChatButton()})
+	}
+	return withEventRefs(markup, event)
+}
+
+func (c *Chat) withExpandButton(markup tg.InlineKeyboardMarkup, event *frf.Event) tg.InlineKeyboardMarkup {
+	p := message.NewPrinter(c.State.Language)
+
+	markup.InlineKeyboard = append(markup.InlineKeyboard, []tg.InlineKeyboardButton{
+		tg.NewInlineKeyboardButtonData(
+			emoji.Parse(p.Sprintf(":arrow_down: Expand")),
+			withEventRef(doExpand, event),
+		),
+	})
+	return markup
 }
 
 func (c *Chat) postButtonsMore(event *frf.Event) tg.InlineKeyboardMarkup {
@@ -89,13 +105,51 @@ func (c *Chat) postButtonsMore(event *frf.Event) tg.InlineKeyboardMarkup {
 		}
 	}
 
-	return tg.NewInlineKeyboardMarkup(row)
+	var row2 []tg.InlineKeyboardButton
+	if event.Post != nil {
+		saved, err := c.Should(c.isPostSaved(event.PostID))
+		if err == nil {
+			if saved.(bool) {
+				row2 = append(row2, tg.NewInlineKeyboardButtonData(
+					emoji.Parse(p.Sprintf(":x: Unsave")),
+					doUnsavePost,
+				))
+			} else {
+				row2 = append(row2, tg.NewInlineKeyboardButtonData(
+					emoji.Parse(p.Sprintf(":bookmark: Save")),
+					doSavePost,
+				))
+			}
+		}
+		row2 = append(row2, tg.NewInlineKeyboardButtonData(
+			emoji.Parse(p.Sprintf(":alarm_clock: Remind me\u2026")),
+			doRemindMenu,
+		))
+	}
+	if event.CreatedUser != nil || event.Group != nil || event.Post != nil {
+		row2 = append(row2, tg.NewInlineKeyboardButtonData(
+			emoji.Parse(p.Sprintf(":mute: Mute\u2026")),
+			doMuteMenu,
+		))
+	}
+	if len(c.adminGroups(event)) > 0 {
+		row2 = append(row2, tg.NewInlineKeyboardButtonData(
+			emoji.Parse(p.Sprintf(":cop: Moderate\u2026")),
+			doModMenu,
+		))
+	}
+
+	markup := tg.NewInlineKeyboardMarkup(row)
+	if len(row2) > 0 {
+		markup.InlineKeyboard = append(markup.InlineKeyboard, row2)
+	}
+	return withEventRefs(markup, event)
 }
 
 func (c *Chat) sentCommentButtons(event *frf.Event, commentID uuid.UUID) tg.InlineKeyboardMarkup {
 	p := message.NewPrinter(c.State.Language)
 
-	return tg.NewInlineKeyboardMarkup([]tg.InlineKeyboardButton{
+	return withEventRefs(tg.NewInlineKeyboardMarkup([]tg.InlineKeyboardButton{
 		tg.NewInlineKeyboardButtonData(
 			emoji.Parse(p.Sprintf(":speech_balloon: Comment more")),
 			doReply,
@@ -105,7 +159,7 @@ func (c *Chat) sentCommentButtons(event *frf.Event, commentID uuid.UUID) tg.Inli
 			fmt.Sprintf("https://%s/posts/%s#comment-%s",
 				c.frfAPI().HostName, event.PostID, commentID),
 		),
-	})
+	}), event)
 }
 
 func (c *Chat) subscrButtons(event *frf.Event) tg.InlineKeyboardMarkup {
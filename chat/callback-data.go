@@ -1,6 +1,14 @@
 package chat
 
-import "strings"
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/FreeFeed/freefeed-tg-client/frf"
+	tg "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/gofrs/uuid"
+)
 
 const (
 	doReply         = "e:reply"
@@ -13,8 +21,106 @@ const (
 	doUntrackPost   = "e:untrackPost"
 	doLikeComment   = "e:likeComment"
 	doUnlikeComment = "e:unlikeComment"
+	doExpand        = "e:expand"
+	doDirectChat    = "e:directChat"
+	doMuteMenu      = "e:muteMenu"
+	doRemindMenu    = "e:remindMenu"
+	doSavePost      = "e:savePost"
+	doUnsavePost    = "e:unsavePost"
+	doModMenu       = "e:modMenu"
+
+	// Followed by the mute kind and (optionally) the duration in hours
+	muteActionPrefix = "e:mute:"
+	// Followed by the reminder delay preset or "custom"
+	remindActionPrefix = "e:remind:"
+	// Followed by the moderation action and (optionally) the group name
+	modActionPrefix  = "e:mod:"
+	modConfirmPrefix = "e:modOK:"
+
+	doRenewToken = "renewToken"
 )
 
 func isEventAction(action string) bool {
 	return strings.HasPrefix(action, "e:")
 }
+
+// The event actions may be followed by the event reference: the separator and
+// the base64-encoded post ID and (optionally) comment ID. It allows to restore
+// the event when its SentMsgRec is already evicted from the store.
+const eventRefSeparator = "|"
+
+// maxCallbackDataLength is the Telegram limit of the callback data length
+const maxCallbackDataLength = 64
+
+var refEncoding = base64.RawURLEncoding
+
+// withEventRef adds the event reference to the event action data, if it fits
+// into the callback data limit.
+func withEventRef(data string, event *frf.Event) string {
+	if !isEventAction(data) || strings.Contains(data, eventRefSeparator) ||
+		event == nil || event.PostID == uuid.Nil {
+		return data
+	}
+
+	ref := event.PostID.Bytes()
+	if event.CommentID != uuid.Nil {
+		ref = append(ref, event.CommentID.Bytes()...)
+	}
+	result := data + eventRefSeparator + refEncoding.EncodeToString(ref)
+	if len(result) > maxCallbackDataLength {
+		// Better no reference than the incomplete one
+		return data
+	}
+	return result
+}
+
+// withEventRefs adds the event reference to all event actions of the markup.
+func withEventRefs(markup tg.InlineKeyboardMarkup, event *frf.Event) tg.InlineKeyboardMarkup {
+	rows := make([][]tg.InlineKeyboardButton, len(markup.InlineKeyboard))
+	for i, row := range markup.InlineKeyboard {
+		rows[i] = make([]tg.InlineKeyboardButton, len(row))
+		for j, btn := range row {
+			if btn.CallbackData != nil {
+				data := withEventRef(*btn.CallbackData, event)
+				btn.CallbackData = &data
+			}
+			rows[i][j] = btn
+		}
+	}
+	return tg.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+// splitEventData splits the event action data to the action and the event
+// reference (empty for the old-style data).
+func splitEventData(data string) (action, ref string) {
+	action, ref, _ = strings.Cut(data, eventRefSeparator)
+	return action, ref
+}
+
+// eventFromRef restores the event from its reference. The restored event has
+// only the post and comment IDs, and the synthetic type that renderEvent can
+// render.
+func eventFromRef(ref string) (*frf.Event, error) {
+	data, err := refEncoding.DecodeString(ref)
+	if err != nil || (len(data) != uuid.Size && len(data) != 2*uuid.Size) {
+		return nil, fmt.Errorf("invalid event reference: %q", ref)
+	}
+
+	event := &frf.Event{Type: timelinePostEvent}
+	event.PostID = uuid.FromBytesOrNil(data[:uuid.Size])
+	if len(data) == 2*uuid.Size {
+		event.Type = "__comment:new"
+		event.CommentID = uuid.FromBytesOrNil(data[uuid.Size:])
+	}
+	return event, nil
+}
+
+// parseIDData parses the "prefix<uuid>" callback data of the list buttons.
+func parseIDData(prefix, data string) (uuid.UUID, bool) {
+	data, ok := strings.CutPrefix(data, prefix)
+	if !ok {
+		return uuid.Nil, false
+	}
+	id, err := uuid.FromString(data)
+	return id, err == nil
+}
@@ -0,0 +1,76 @@
+package chat
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/FreeFeed/freefeed-tg-client/frf"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventRef(t *testing.T) {
+	postID := uuid.Must(uuid.NewV4())
+	commentID := uuid.Must(uuid.NewV4())
+
+	t.Run("post", func(t *testing.T) {
+		data := withEventRef(doPostMore, &frf.Event{PostID: postID})
+		action, ref := splitEventData(data)
+		assert.Equal(t, doPostMore, action)
+
+		event, err := eventFromRef(ref)
+		if assert.NoError(t, err) {
+			assert.Equal(t, postID, event.PostID)
+			assert.Equal(t, uuid.Nil, event.CommentID)
+			assert.Equal(t, timelinePostEvent, event.Type)
+		}
+	})
+
+	t.Run("comment", func(t *testing.T) {
+		data := withEventRef(doLikeComment, &frf.Event{PostID: postID, CommentID: commentID})
+		assert.LessOrEqual(t, len(data), maxCallbackDataLength)
+		action, ref := splitEventData(data)
+		assert.Equal(t, doLikeComment, action)
+
+		event, err := eventFromRef(ref)
+		if assert.NoError(t, err) {
+			assert.Equal(t, postID, event.PostID)
+			assert.Equal(t, commentID, event.CommentID)
+			assert.Equal(t, "__comment:new", event.Type)
+		}
+	})
+
+	t.Run("too long", func(t *testing.T) {
+		long := modConfirmPrefix + modBlockUser + ":" + strings.Repeat("g", 25)
+		data := withEventRef(long, &frf.Event{PostID: postID, CommentID: commentID})
+		assert.Equal(t, long, data)
+	})
+
+	t.Run("not an event action", func(t *testing.T) {
+		assert.Equal(t, "unmute:1", withEventRef("unmute:1", &frf.Event{PostID: postID}))
+	})
+
+	t.Run("legacy data", func(t *testing.T) {
+		action, ref := splitEventData(doReply)
+		assert.Equal(t, doReply, action)
+		assert.Empty(t, ref)
+	})
+
+	t.Run("invalid ref", func(t *testing.T) {
+		_, err := eventFromRef("abc")
+		assert.Error(t, err)
+	})
+}
+
+func TestParseIDData(t *testing.T) {
+	id := uuid.Must(uuid.NewV4())
+
+	parsed, ok := parseIDData(unmutePrefix, unmutePrefix+id.String())
+	assert.True(t, ok)
+	assert.Equal(t, id, parsed)
+
+	for _, data := range []string{unmutePrefix, unmutePrefix + "xxx", unwatchPrefix + id.String()} {
+		_, ok := parseIDData(unmutePrefix, data)
+		assert.False(t, ok, data)
+	}
+}
@@ -1,10 +1,12 @@
 package chat
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/FreeFeed/freefeed-tg-client/frf"
 	"github.com/FreeFeed/freefeed-tg-client/store"
+	"github.com/FreeFeed/freefeed-tg-client/types"
 	"github.com/davidmz/debug-log"
 	"github.com/enescakir/emoji"
 	tg "github.com/go-telegram-bot-api/telegram-bot-api/v5"
@@ -17,6 +19,8 @@ type Chat struct {
 
 	dLog debug.Logger
 	eLog debug.Logger
+
+	expectationPrinted bool
 }
 
 func New(id ID, app App) (*Chat, error) {
@@ -42,18 +46,24 @@ func (c *Chat) frfAPIWithToken(accessToken string) *frf.API {
 	return api
 }
 
-func (c *Chat) frfAPI() *frf.API { return c.frfAPIWithToken(c.State.AccessToken) }
+func (c *Chat) frfAPI() *frf.API {
+	api := c.frfAPIWithToken(c.State.AccessToken)
+	api.OnUnauthorized = c.onTokenRevoked
+	return api
+}
 
 func (c *Chat) saveState() error   { return c.ShouldOK(c.App.SaveState(c.State)) }
 func (c *Chat) deleteState() error { return c.ShouldOK(c.App.DeleteState(c.ID)) }
 
 func (c *Chat) ShouldSend(msg tg.Chattable) (tg.Message, error) {
 	m, err := c.Should(c.App.Send(msg))
+	c.checkUnreachable(err)
 	return m.(tg.Message), err
 }
 
 func (c *Chat) ShouldSendAndSave(msg tg.Chattable, rec store.SentMsgRec) (tg.Message, error) {
 	m, err := c.Should(c.App.Send(msg))
+	c.checkUnreachable(err)
 	if err == nil {
 		rec.MessageID = m.(tg.Message).MessageID
 		c.ShouldOK(c.App.PutMsgRec(c.ID, rec))
@@ -61,6 +71,24 @@ func (c *Chat) ShouldSendAndSave(msg tg.Chattable, rec store.SentMsgRec) (tg.Mes
 	return m.(tg.Message), err
 }
 
+// checkUnreachable marks the in-memory state as inactive if the chat was
+// deactivated by the sending error, so the later saveState keeps it inactive.
+func (c *Chat) checkUnreachable(err error) {
+	if errors.Is(err, types.ErrChatUnreachable) && c.State.IsActive() {
+		c.State.Deactivate()
+	}
+}
+
+// editListMessage replaces the text and the buttons of the list message (like
+// /filters or /mutes) after the list is changed.
+func (c *Chat) editListMessage(messageID int, text string, markup *tg.InlineKeyboardMarkup) {
+	msg := tg.NewEditMessageText(c.ID, messageID, text)
+	msg.ParseMode = "HTML"
+	msg.DisableWebPagePreview = true
+	msg.ReplyMarkup = markup
+	c.ShouldSend(msg)
+}
+
 func (c *Chat) newHTMLMessage(text string) *tg.MessageConfig {
 	return c.newRawHTMLMessage(c.App.Linkify(emoji.Parse(text)))
 }
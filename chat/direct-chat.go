@@ -0,0 +1,123 @@
+package chat
+
+import (
+	"strings"
+
+	"github.com/FreeFeed/freefeed-tg-client/frf"
+	"github.com/enescakir/emoji"
+	tg "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/gofrs/uuid"
+	"golang.org/x/text/message"
+)
+
+// handleChatCommand starts the conversation mode with the latest direct
+// message of the given user.
+func (c *Chat) handleChatCommand(msg *tg.Message) {
+	p := message.NewPrinter(c.State.Language)
+
+	userName := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(msg.CommandArguments()), "@"))
+	if userName == "" {
+		c.ShouldSend(c.newHTMLMessage(p.Sprintf("Usage: /chat @username")))
+		return
+	}
+
+	user, err := c.frfAPI().GetUser(userName)
+	if err != nil {
+		c.ShouldSend(c.newHTMLMessage(p.Sprintf("Cannot find user @%s: %v", userName, err)))
+		return
+	}
+
+	directs, err := c.frfAPI().GetDirects()
+	if err != nil {
+		c.ShouldSend(c.newHTMLMessage(p.Sprintf(":alien: Cannot load direct messages: %v", err)))
+		return
+	}
+
+	for _, post := range directs {
+		if post.CreatedBy == user.ID || post.InNamedFeedOf(frf.DirectsFeedName, user.ID) {
+			c.startDirectChat(post, user)
+			return
+		}
+	}
+
+	c.ShouldSend(c.newHTMLMessage(p.Sprintf("You have no recent direct messages with %s.", user)))
+}
+
+func (c *Chat) handleEndChatCommand() {
+	p := message.NewPrinter(c.State.Language)
+
+	if c.State.DirectChatPostID == uuid.Nil {
+		c.ShouldSend(c.newHTMLMessage(p.Sprintf("You are not in the conversation mode.")))
+		return
+	}
+
+	userName := "@" + c.State.DirectChatUser
+	c.State.EndDirectChat()
+	c.ShouldOK(c.saveState())
+	c.ShouldSend(c.newHTMLMessage(p.Sprintf("The conversation with %s is finished.", userName)))
+}
+
+func (c *Chat) startDirectChat(post *frf.Post, user *frf.User) {
+	p := message.NewPrinter(c.State.Language)
+
+	c.State.StartDirectChat(post.ID, user.Name)
+	c.ShouldOK(c.saveState())
+	c.App.ResumeEvents(c.ID)
+
+	c.ShouldSend(c.newHTMLMessage(p.Sprintf(
+		":speech_balloon: You are chatting with %s in the direct message \"%s\". "+
+			"Everything you write will be posted as a comment. Use /endchat to finish.",
+		user,
+		c.App.ContentOf(post.Digest()),
+	)))
+}
+
+// sendDirectChatComment posts the message text as a comment to the direct post
+// of the conversation mode.
+func (c *Chat) sendDirectChatComment(msg *tg.Message) {
+	p := message.NewPrinter(c.State.Language)
+
+	if msg.Text == "" {
+		c.ShouldSend(c.newHTMLMessage(p.Sprintf("Can not send a comment without a text")))
+		return
+	}
+
+	if _, err := c.frfAPI().AddComment(c.State.DirectChatPostID, msg.Text); err != nil {
+		errMsg := c.newHTMLMessage(p.Sprintf("Error creating comment: %v", err))
+		errMsg.ReplyToMessageID = msg.MessageID
+		c.ShouldSend(errMsg)
+	}
+}
+
+// isDirectChatEvent returns true if the event is a comment to the direct post
+// of the conversation mode.
+func (c *Chat) isDirectChatEvent(event *frf.Event) bool {
+	return c.State.DirectChatPostID != uuid.Nil &&
+		event.PostID == c.State.DirectChatPostID &&
+		event.CommentID != uuid.Nil
+}
+
+// renderDirectChatComment renders the comment in the conversation mode: just
+// the author and the text, without headers and buttons.
+func (c *Chat) renderDirectChatComment(event *frf.Event) tg.Chattable {
+	if event.Comment == nil {
+		return nil
+	}
+
+	author := ""
+	if event.CreatedUser != nil {
+		author = c.App.Linkify(event.CreatedUser.String()) + ": "
+	}
+
+	return c.newRawHTMLMessage(author + c.App.ContentOf(
+		c.App.LinkifyComment(event.Comment.Body, event.Post, event.Comment.ID),
+	))
+}
+
+func (c *Chat) directChatButton() tg.InlineKeyboardButton {
+	p := message.NewPrinter(c.State.Language)
+	return tg.NewInlineKeyboardButtonData(
+		emoji.Parse(p.Sprintf(":speech_balloon: Chat")),
+		doDirectChat,
+	)
+}
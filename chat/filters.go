@@ -0,0 +1,275 @@
+package chat
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/FreeFeed/freefeed-tg-client/frf"
+	"github.com/FreeFeed/freefeed-tg-client/store"
+	"github.com/enescakir/emoji"
+	tg "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/gofrs/uuid"
+	"golang.org/x/text/message"
+)
+
+const (
+	// Maximum number of filters per chat
+	maxFilters = 20
+	// Maximum length of the filter pattern
+	maxFilterPatternLength = 200
+	// Maximum number of compiled patterns in cache
+	maxCachedRegexps = 1000
+)
+
+const unfilterPrefix = "unfilter:"
+
+// Compiled filter patterns, shared by all chats
+var regexpCache = struct {
+	sync.Mutex
+	items map[string]*regexp.Regexp
+}{items: make(map[string]*regexp.Regexp)}
+
+// compileFilter returns the compiled case-insensitive filter pattern. The
+// results are cached, so it is cheap to call it for every event.
+func compileFilter(pattern string) (*regexp.Regexp, error) {
+	regexpCache.Lock()
+	defer regexpCache.Unlock()
+
+	if re, ok := regexpCache.items[pattern]; ok {
+		return re, nil
+	}
+
+	re, err := regexp.Compile("(?i)" + pattern)
+	if err != nil {
+		return nil, err
+	}
+	if len(regexpCache.items) >= maxCachedRegexps {
+		// Just start over, the actual patterns will be compiled again
+		clear(regexpCache.items)
+	}
+	regexpCache.items[pattern] = re
+	return re, nil
+}
+
+// eventText returns the text of the post or comment of the event, or false if
+// the event has no text. The post must be loaded.
+func eventText(event *frf.Event) (string, bool) {
+	if event.CommentID != uuid.Nil {
+		if event.Comment != nil {
+			return event.Comment.Body, true
+		}
+		return "", false
+	}
+	if event.Post != nil {
+		return event.Post.Body, true
+	}
+	return "", false
+}
+
+// matchFilters checks the event against the filters. It returns false if the
+// event should be dropped, and the patterns of the matched "only" and
+// "highlight" filters otherwise.
+func matchFilters(filters []store.Filter, event *frf.Event) ([]string, bool) {
+	text, ok := eventText(event)
+	if !ok || len(filters) == 0 {
+		return nil, true
+	}
+
+	var (
+		matches  []string
+		hasOnly  bool
+		onlyPass bool
+	)
+	for _, f := range filters {
+		re, err := compileFilter(f.Pattern)
+		if err != nil {
+			// Should not happen, patterns are validated on entry
+			continue
+		}
+		if f.Mode == store.FilterOnly {
+			hasOnly = true
+		}
+		if !re.MatchString(text) {
+			continue
+		}
+		switch f.Mode {
+		case store.FilterNever:
+			return nil, false
+		case store.FilterOnly:
+			onlyPass = true
+			matches = append(matches, f.Pattern)
+		case store.FilterHighlight:
+			matches = append(matches, f.Pattern)
+		}
+	}
+	if hasOnly && !onlyPass {
+		return nil, false
+	}
+	return matches, true
+}
+
+// applyFilters loads the event post and checks it against the chat filters.
+func (c *Chat) applyFilters(filters []store.Filter, event *frf.Event) ([]string, bool) {
+	if len(filters) == 0 {
+		return nil, true
+	}
+	if err := event.LoadPost(c.frfAPI()); err != nil {
+		// Will be handled in renderEvent
+		return nil, true
+	}
+	return matchFilters(filters, event)
+}
+
+// withFilterMatches adds the matched filters to the message header.
+func (c *Chat) withFilterMatches(msg tg.Chattable, matches []string) {
+	m, ok := msg.(*tg.MessageConfig)
+	if !ok || len(matches) == 0 {
+		return
+	}
+
+	p := message.NewPrinter(c.State.Language)
+	var codes []string
+	for _, pattern := range matches {
+		codes = append(codes, "<code>"+html.EscapeString(pattern)+"</code>")
+	}
+	header := c.App.Linkify(emoji.Parse(p.Sprintf(":pushpin: Matched filters:")))
+	m.Text = header + " " + strings.Join(codes, ", ") + "\n" + m.Text
+}
+
+func (c *Chat) loadFilters() []store.Filter {
+	filters, _ := c.Should(c.App.ListFilters(c.ID))
+	if filters == nil {
+		return nil
+	}
+	return filters.([]store.Filter)
+}
+
+// handleFilterCommand handles the "/filter" command: without arguments it
+// lists the filters, "/filter add [only|never|highlight] regex" adds the new
+// one.
+func (c *Chat) handleFilterCommand(args string) {
+	p := message.NewPrinter(c.State.Language)
+
+	subCommand, rest, _ := strings.Cut(strings.TrimSpace(args), " ")
+	if subCommand == "" {
+		c.handleFiltersList()
+		return
+	}
+	if subCommand != "add" {
+		c.ShouldSend(c.newHTMLMessage(p.Sprintf("Usage: /filter add [only|never|highlight] regex")))
+		return
+	}
+
+	mode := store.FilterNever
+	firstWord, pattern, _ := strings.Cut(strings.TrimSpace(rest), " ")
+	switch m := store.FilterMode(firstWord); m {
+	case store.FilterOnly, store.FilterNever, store.FilterHighlight:
+		mode = m
+	default:
+		pattern = strings.TrimSpace(rest)
+	}
+	pattern = strings.TrimSpace(pattern)
+
+	if err := validateFilterPattern(pattern); err != nil {
+		c.ShouldSend(c.newRawHTMLMessage(html.EscapeString(p.Sprintf("Invalid filter: %v", err))))
+		return
+	}
+
+	filters, err := c.App.ListFilters(c.ID)
+	if c.ShouldOK(err) != nil {
+		return
+	}
+	if len(filters) >= maxFilters {
+		c.ShouldSend(c.newHTMLMessage(p.Sprintf(
+			"You cannot have more than %d filters. Use /filter to remove some of them.",
+			maxFilters,
+		)))
+		return
+	}
+
+	filter := store.Filter{ID: uuid.Must(uuid.NewV4()), Mode: mode, Pattern: pattern}
+	if c.ShouldOK(c.App.AddFilter(c.ID, filter)) != nil {
+		return
+	}
+	description := c.filterDescription(p, filter)
+	c.ShouldSend(c.newRawHTMLMessage(html.EscapeString(p.Sprintf("Filter is added: %s", description))))
+}
+
+func validateFilterPattern(pattern string) error {
+	if pattern == "" {
+		return fmt.Errorf("empty pattern")
+	}
+	if len(pattern) > maxFilterPatternLength {
+		return fmt.Errorf("pattern is longer than %d characters", maxFilterPatternLength)
+	}
+	_, err := compileFilter(pattern)
+	return err
+}
+
+func (c *Chat) filterDescription(p *message.Printer, f store.Filter) string {
+	pattern := f.Pattern
+	switch f.Mode {
+	case store.FilterOnly:
+		return p.Sprintf("only notify if matches /%s/", pattern)
+	case store.FilterHighlight:
+		return p.Sprintf("highlight if matches /%s/", pattern)
+	default:
+		return p.Sprintf("never notify if matches /%s/", pattern)
+	}
+}
+
+// handleFiltersList lists the filters with the buttons to remove them.
+func (c *Chat) handleFiltersList() {
+	text, markup := c.renderFiltersList()
+	msg := c.newRawHTMLMessage(text)
+	msg.ReplyMarkup = markup
+	c.ShouldSend(msg)
+}
+
+func (c *Chat) renderFiltersList() (string, *tg.InlineKeyboardMarkup) {
+	p := message.NewPrinter(c.State.Language)
+
+	filters, err := c.App.ListFilters(c.ID)
+	if err != nil {
+		c.errorLog().Print(err)
+		return c.App.Linkify(p.Sprintf("Cannot load the filters: %v", err)), nil
+	}
+	if len(filters) == 0 {
+		return c.App.Linkify(p.Sprintf("You have no filters. Use \"/filter add regex\" to add one.")), nil
+	}
+
+	var (
+		lines []string
+		rows  [][]tg.InlineKeyboardButton
+	)
+	for i, f := range filters {
+		number := i + 1
+		lines = append(lines, fmt.Sprintf("%d. %s", number, html.EscapeString(c.filterDescription(p, f))))
+		rows = append(rows, []tg.InlineKeyboardButton{
+			tg.NewInlineKeyboardButtonData(
+				emoji.Parse(p.Sprintf(":x: Remove #%d", number)),
+				unfilterPrefix+f.ID.String(),
+			),
+		})
+	}
+
+	text := c.App.Linkify(p.Sprintf("Your filters:")) + "\n\n" + strings.Join(lines, "\n")
+	return text, &tg.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+// handleUnfilterCallback removes the filter and updates the list.
+func (c *Chat) handleUnfilterCallback(cbQuery *tg.CallbackQuery, filterID uuid.UUID) {
+	p := message.NewPrinter(c.State.Language)
+
+	text := p.Sprintf("Filter is removed")
+	if err := c.App.DeleteFilter(c.ID, filterID); err != nil {
+		text = p.Sprintf("Cannot remove filter: %v", err)
+	}
+	c.ShouldSend(tg.CallbackConfig{CallbackQueryID: cbQuery.ID, Text: text})
+
+	newText, markup := c.renderFiltersList()
+	c.editListMessage(cbQuery.Message.MessageID, newText, markup)
+}
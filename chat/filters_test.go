@@ -0,0 +1,62 @@
+package chat
+
+import (
+	"testing"
+
+	"github.com/FreeFeed/freefeed-tg-client/frf"
+	"github.com/FreeFeed/freefeed-tg-client/store"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchFilters(t *testing.T) {
+	postEvent := func(body string) *frf.Event {
+		return &frf.Event{PostID: uuid.Must(uuid.NewV4()), Post: &frf.Post{Body: body}}
+	}
+	never := store.Filter{Mode: store.FilterNever, Pattern: `\bcats?\b`}
+	only := store.Filter{Mode: store.FilterOnly, Pattern: "golang|rust"}
+	highlight := store.Filter{Mode: store.FilterHighlight, Pattern: "freefeed"}
+
+	tests := []struct {
+		name    string
+		filters []store.Filter
+		event   *frf.Event
+		matches []string
+		pass    bool
+	}{
+		{"no filters", nil, postEvent("My cat"), nil, true},
+		{"never matched", []store.Filter{never}, postEvent("My CAT"), nil, false},
+		{"never not matched", []store.Filter{never}, postEvent("My catalog"), nil, true},
+		{"only matched", []store.Filter{only, highlight}, postEvent("Golang on FreeFeed"), []string{"golang|rust", "freefeed"}, true},
+		{"only not matched", []store.Filter{only}, postEvent("Python"), nil, false},
+		{"never wins", []store.Filter{only, never}, postEvent("Rust cat"), nil, false},
+		{"highlight", []store.Filter{highlight}, postEvent("freefeed"), []string{"freefeed"}, true},
+		{
+			"comment text",
+			[]store.Filter{never},
+			&frf.Event{
+				PostID:    uuid.Must(uuid.NewV4()),
+				CommentID: uuid.Must(uuid.NewV4()),
+				Post:      &frf.Post{Body: "Dogs"},
+				Comment:   &frf.Comment{Body: "and cats"},
+			},
+			nil,
+			false,
+		},
+		{"no text", []store.Filter{only}, &frf.Event{Type: "user_subscribed"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, pass := matchFilters(tt.filters, tt.event)
+			assert.Equal(t, tt.pass, pass)
+			assert.Equal(t, tt.matches, matches)
+		})
+	}
+}
+
+func TestValidateFilterPattern(t *testing.T) {
+	assert.NoError(t, validateFilterPattern(`cats?`))
+	assert.Error(t, validateFilterPattern(``))
+	assert.Error(t, validateFilterPattern(`(cats`))
+}
@@ -0,0 +1,91 @@
+package chat
+
+import (
+	"strings"
+
+	"github.com/FreeFeed/freefeed-tg-client/store"
+	"github.com/FreeFeed/freefeed-tg-client/types"
+	"github.com/gofrs/uuid"
+	"golang.org/x/text/message"
+)
+
+// handleFollowCommand handles the "/follow @user" and "/follow group"
+// commands. Without arguments, it lists the followed feeds.
+func (c *Chat) handleFollowCommand(args string) {
+	p := message.NewPrinter(c.State.Language)
+
+	name := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(args), "@"))
+	if name == "" {
+		c.listFollows()
+		return
+	}
+	if !userNameRe.MatchString(name) {
+		c.ShouldSend(c.newHTMLMessage(p.Sprintf("Usage: /follow @username or /follow group")))
+		return
+	}
+
+	// Check that the feed is accessible and get its ID
+	timeline, err := c.frfAPI().GetTimeline(name, 0, 1)
+	if err == nil && timeline.FeedID == uuid.Nil {
+		err = store.ErrNotFound
+	}
+	if err != nil {
+		c.ShouldSend(c.newHTMLMessage(p.Sprintf("Cannot follow @%s: %v", name, err)))
+		return
+	}
+
+	if c.ShouldOK(c.App.TrackFeed(c.ID, store.TrackedFeed{ID: timeline.FeedID, UserName: name})) != nil {
+		return
+	}
+	// RT connection may be not opened yet, it will subscribe on connect
+	c.App.RTSend(c.ID, "subscribe", types.UserSubsPayload{TimelineIDs: []uuid.UUID{timeline.FeedID}}, nil)
+
+	c.ShouldSend(c.newHTMLMessage(p.Sprintf("You are following @%s now. New posts will be shown here.", name)))
+}
+
+// handleUnfollowCommand handles the "/unfollow @user" and "/unfollow group"
+// commands.
+func (c *Chat) handleUnfollowCommand(args string) {
+	p := message.NewPrinter(c.State.Language)
+
+	name := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(args), "@"))
+
+	tracked, err := c.App.TrackedEntities(c.ID)
+	if c.ShouldOK(err) != nil {
+		return
+	}
+
+	for _, feed := range tracked.Feeds {
+		if feed.UserName != name {
+			continue
+		}
+		if c.ShouldOK(c.App.UntrackFeed(c.ID, feed.ID)) != nil {
+			return
+		}
+		c.App.RTSend(c.ID, "unsubscribe", types.UserSubsPayload{TimelineIDs: []uuid.UUID{feed.ID}}, nil)
+		c.ShouldSend(c.newHTMLMessage(p.Sprintf("You don't follow @%s anymore.", name)))
+		return
+	}
+
+	c.ShouldSend(c.newHTMLMessage(p.Sprintf("You don't follow @%s.", name)))
+}
+
+func (c *Chat) listFollows() {
+	p := message.NewPrinter(c.State.Language)
+
+	tracked, err := c.App.TrackedEntities(c.ID)
+	if c.ShouldOK(err) != nil {
+		return
+	}
+	if len(tracked.Feeds) == 0 {
+		c.ShouldSend(c.newHTMLMessage(p.Sprintf("You don't follow anyone yet. Use /follow @username or /follow group.")))
+		return
+	}
+
+	names := make([]string, len(tracked.Feeds))
+	for i, feed := range tracked.Feeds {
+		names[i] = feed.UserName
+	}
+	followsList := formatUserNames(names)
+	c.ShouldSend(c.newHTMLMessage(p.Sprintf("You follow: %s. Use /unfollow to stop.", followsList)))
+}
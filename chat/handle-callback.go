@@ -2,13 +2,12 @@ package chat
 
 import (
 	"errors"
+	"strings"
 
+	"github.com/FreeFeed/freefeed-tg-client/frf"
 	"github.com/FreeFeed/freefeed-tg-client/store"
-	"github.com/FreeFeed/freefeed-tg-client/types"
-	"github.com/davidmz/go-try"
 	"github.com/enescakir/emoji"
 	tg "github.com/go-telegram-bot-api/telegram-bot-api/v5"
-	"github.com/gofrs/uuid"
 	"golang.org/x/text/language"
 	"golang.org/x/text/message"
 )
@@ -23,6 +22,15 @@ func (c *Chat) handleCallback(update tg.Update) {
 
 	p := message.NewPrinter(c.State.Language)
 
+	if c.State.TopicsMode && !c.isTopicsOwner(cbQuery.From) {
+		// The buttons act with the owner's token
+		c.ShouldSend(tg.CallbackConfig{
+			CallbackQueryID: cbQuery.ID,
+			Text:            p.Sprintf("Only the user who enabled the topics mode can use these buttons"),
+		})
+		return
+	}
+
 	cbData := cbQuery.Data
 	c.debugLog().Println("Callback Data: ", cbData)
 
@@ -49,7 +57,20 @@ func (c *Chat) handleCallback(update tg.Update) {
 			// TODO
 		}
 	} else if isEventAction(cbData) {
+		action, ref := splitEventData(cbData)
+		cbData = action
+
 		eventRec, err := c.App.GetMsgRec(c.ID, msg.MessageID)
+		restored := false
+		if errors.Is(err, store.ErrNotFound) && ref != "" {
+			// The message record is evicted, but the event can be restored
+			// from the callback data
+			var event *frf.Event
+			if event, err = eventFromRef(ref); err == nil {
+				eventRec = store.SentMsgRec{MessageID: msg.MessageID, Event: event, Truncated: action == doExpand}
+				restored = true
+			}
+		}
 		if err != nil {
 			text := emoji.Parse(p.Sprintf(":warning: Cannot load event: %v", err))
 			if errors.Is(err, store.ErrNotFound) {
@@ -72,6 +93,15 @@ func (c *Chat) handleCallback(update tg.Update) {
 			return
 		}
 
+		if restored {
+			if event.CreatedUser == nil {
+				event.CreatedUser = moderationTarget(event)
+			}
+			// Save the restored record for the actions that will need it later
+			// (such as reply or custom reminder)
+			c.ShouldOK(c.App.PutMsgRec(c.ID, eventRec))
+		}
+
 		if (cbData == doReply || cbData == doReplyAt) && event.Post != nil {
 			c.State.ClearExpectations()
 			c.State.Expectation = store.ExpectComment
@@ -79,28 +109,18 @@ func (c *Chat) handleCallback(update tg.Update) {
 			if msg.ReplyToMessage != nil {
 				c.State.ReactToMessageID = msg.ReplyToMessage.MessageID
 			}
-			if cbData == doReplyAt {
+			if cbData == doReplyAt && event.CreatedUser != nil {
 				c.State.CommentPrefix = event.CreatedUser.String() + " "
 			}
 			c.saveState()
 			c.App.PauseEvents(c.ID)
 			c.ShouldSend(tg.CallbackConfig{CallbackQueryID: cbQuery.ID})
 		} else if cbData == doAcceptRequest || cbData == doRejectRequest {
-			var err error
-			if event.Group == nil {
-				if cbData == doAcceptRequest {
-					err = c.frfAPI().AcceptSubscriptionRequest(event.CreatedUser.Name)
-				} else {
-					err = c.frfAPI().RejectSubscriptionRequest(event.CreatedUser.Name)
-				}
-			} else {
-				if cbData == doAcceptRequest {
-					err = c.frfAPI().AcceptGroupSubscriptionRequest(event.CreatedUser.Name, event.Group.Name)
-				} else {
-					err = c.frfAPI().RejectGroupSubscriptionRequest(event.CreatedUser.Name, event.Group.Name)
-				}
+			req := subscriptionRequest{user: event.CreatedUser.Name}
+			if event.Group != nil {
+				req.group = event.Group.Name
 			}
-			if err != nil {
+			if err := c.answerRequest(req, cbData == doAcceptRequest); err != nil {
 				c.ShouldSend(tg.CallbackConfig{
 					CallbackQueryID: cbQuery.ID,
 					Text:            emoji.Parse(p.Sprintf(":warning: FreeFeed error: %v", err)),
@@ -120,26 +140,39 @@ func (c *Chat) handleCallback(update tg.Update) {
 			msg.ReplyMarkup = &tg.InlineKeyboardMarkup{InlineKeyboard: [][]tg.InlineKeyboardButton{}}
 			c.ShouldSend(msg)
 
+		} else if cbData == doDirectChat && event.Post != nil && event.CreatedUser != nil {
+			c.ShouldSend(tg.CallbackConfig{CallbackQueryID: cbQuery.ID})
+			c.startDirectChat(event.Post, event.CreatedUser)
+
 		} else if cbData == doPostMore {
 			msg := tg.NewEditMessageReplyMarkup(c.ID, msg.MessageID, c.postButtonsMore(event))
 			c.ShouldSend(msg)
 
 		} else if cbData == doPostBack {
+			buttons := c.postButtons(event)
+			if eventRec.Truncated {
+				buttons = c.withExpandButton(buttons, event)
+			}
+			msg := tg.NewEditMessageReplyMarkup(c.ID, msg.MessageID, buttons)
+			c.ShouldSend(msg)
+
+		} else if cbData == doExpand {
+			c.ShouldSend(tg.CallbackConfig{CallbackQueryID: cbQuery.ID})
+			if !eventRec.Truncated {
+				return
+			}
+
+			c.expandMessage(msg.MessageID, eventRec)
+
+			eventRec.Truncated = false
+			eventRec.Rest = nil
+			c.ShouldOK(c.App.PutMsgRec(c.ID, eventRec))
 			msg := tg.NewEditMessageReplyMarkup(c.ID, msg.MessageID, c.postButtons(event))
 			c.ShouldSend(msg)
 
 		} else if cbData == doTrackPost || cbData == doUntrackPost {
-			err := try.Func(func() {
-				// Turn off legacy post tracking, if necessary
-				if try.ItVal(c.App.IsPostTracked(c.ID, event.PostID)) {
-					try.It(c.App.UntrackPost(c.ID, event.PostID))
-					// RT unsubscribe
-					try.It(c.App.RTSend(c.ID, "unsubscribe", types.UserSubsPayload{PostIDs: []uuid.UUID{event.PostID}}, nil))
-				}
-
-				ok := try.ItVal(c.frfAPI().NotifyOfAllComments(event.PostID, cbData == doTrackPost))
-				event.Post.NotifyOfAllComments = ok
-			})()
+			ok, err := c.setCommentsNotify(event.PostID, cbData == doTrackPost)
+			event.Post.NotifyOfAllComments = ok
 			if err != nil {
 				c.errorLog().Print(err)
 				c.ShouldSend(tg.CallbackConfig{
@@ -151,6 +184,47 @@ func (c *Chat) handleCallback(update tg.Update) {
 			msg := tg.NewEditMessageReplyMarkup(c.ID, msg.MessageID, c.postButtonsMore(event))
 			c.ShouldSend(msg)
 
+		} else if cbData == doMuteMenu {
+			c.ShouldSend(tg.CallbackConfig{CallbackQueryID: cbQuery.ID})
+			msg := tg.NewEditMessageReplyMarkup(c.ID, msg.MessageID, c.postButtonsMute(event))
+			c.ShouldSend(msg)
+
+		} else if strings.HasPrefix(cbData, muteActionPrefix) {
+			c.handleMuteCallback(cbQuery, cbData, &eventRec)
+
+		} else if cbData == doRemindMenu {
+			c.ShouldSend(tg.CallbackConfig{CallbackQueryID: cbQuery.ID})
+			msg := tg.NewEditMessageReplyMarkup(c.ID, msg.MessageID, c.postButtonsRemind(event))
+			c.ShouldSend(msg)
+
+		} else if strings.HasPrefix(cbData, remindActionPrefix) {
+			c.handleRemindCallback(cbQuery, cbData, &eventRec)
+
+		} else if (cbData == doSavePost || cbData == doUnsavePost) && event.Post != nil {
+			if err := c.setPostSaved(event, cbData == doSavePost); err != nil {
+				c.errorLog().Print(err)
+				c.ShouldSend(tg.CallbackConfig{
+					CallbackQueryID: cbQuery.ID,
+					Text:            emoji.Parse(p.Sprintf(":warning: Error: %v", err)),
+				})
+				return
+			}
+			text := p.Sprintf("Post is saved, see /saved")
+			if cbData == doUnsavePost {
+				text = p.Sprintf("Post is removed from saved")
+			}
+			c.ShouldSend(tg.CallbackConfig{CallbackQueryID: cbQuery.ID, Text: text})
+			msg := tg.NewEditMessageReplyMarkup(c.ID, msg.MessageID, c.postButtonsMore(event))
+			c.ShouldSend(msg)
+
+		} else if cbData == doModMenu {
+			c.ShouldSend(tg.CallbackConfig{CallbackQueryID: cbQuery.ID})
+			msg := tg.NewEditMessageReplyMarkup(c.ID, msg.MessageID, c.postButtonsModerate(event))
+			c.ShouldSend(msg)
+
+		} else if strings.HasPrefix(cbData, modActionPrefix) || strings.HasPrefix(cbData, modConfirmPrefix) {
+			c.handleModerationCallback(cbQuery, cbData, &eventRec)
+
 		} else if cbData == doLikeComment || cbData == doUnlikeComment {
 			var err error
 			if cbData == doLikeComment {
@@ -178,6 +252,45 @@ func (c *Chat) handleCallback(update tg.Update) {
 			})
 		}
 
+	} else if feedPath, offset, ok := parseNextPageData(cbData); ok && c.State.IsAuthorized() {
+		c.ShouldSend(tg.CallbackConfig{CallbackQueryID: cbQuery.ID})
+		// Remove the "Next page" button
+		c.ShouldSend(tg.NewEditMessageReplyMarkup(c.ID, msg.MessageID,
+			tg.InlineKeyboardMarkup{InlineKeyboard: [][]tg.InlineKeyboardButton{}}))
+		c.showTimeline(feedPath, offset)
+	} else if seq, offset, ok := parseSearchPageData(cbData); ok && c.State.IsAuthorized() {
+		if seq != c.State.SearchSeq {
+			c.ShouldSend(tg.CallbackConfig{
+				CallbackQueryID: cbQuery.ID,
+				Text:            p.Sprintf("This search is outdated, please repeat it."),
+			})
+			return
+		}
+		c.ShouldSend(tg.CallbackConfig{CallbackQueryID: cbQuery.ID})
+		c.ShouldSend(tg.NewEditMessageReplyMarkup(c.ID, msg.MessageID,
+			tg.InlineKeyboardMarkup{InlineKeyboard: [][]tg.InlineKeyboardButton{}}))
+		c.showSearchResults(offset)
+	} else if watchID, ok := parseIDData(unwatchPrefix, cbData); ok && c.State.IsAuthorized() {
+		c.handleUnwatchCallback(cbQuery, watchID)
+	} else if postID, ok := parseUntrackData(cbData); ok && c.State.IsAuthorized() {
+		c.handleUntrackCallback(cbQuery, postID)
+	} else if muteID, ok := parseIDData(unmutePrefix, cbData); ok && c.State.IsAuthorized() {
+		c.handleUnmuteCallback(cbQuery, muteID)
+	} else if filterID, ok := parseIDData(unfilterPrefix, cbData); ok && c.State.IsAuthorized() {
+		c.handleUnfilterCallback(cbQuery, filterID)
+	} else if reminderID, ok := parseIDData(unremindPrefix, cbData); ok && c.State.IsAuthorized() {
+		c.handleUnremindCallback(cbQuery, reminderID)
+	} else if offset, ok := parseSavedPageData(cbData); ok && c.State.IsAuthorized() {
+		c.handleSavedPageCallback(cbQuery, offset)
+	} else if postID, offset, ok := parseUnsaveData(cbData); ok && c.State.IsAuthorized() {
+		c.handleUnsaveCallback(cbQuery, postID, offset)
+	} else if action, ok := parseRequestData(cbData); ok && c.State.IsAuthorized() {
+		c.handleRequestCallback(cbQuery, action)
+	} else if cbData == doRenewToken && c.State.IsAuthorized() {
+		c.State.ClearExpectations()
+		c.State.Expectation = store.ExpectAuthToken
+		c.ShouldOK(c.saveState())
+		c.ShouldSend(tg.CallbackConfig{CallbackQueryID: cbQuery.ID})
 	} else if cbData == "cancel" {
 		c.State.ClearExpectations()
 		c.saveState()
@@ -13,10 +13,29 @@ func (c *Chat) handleCommand(update tg.Update) {
 	}
 
 	command := msg.Command()
+	if c.State.TopicsMode && !c.isTopicsOwner(msg.From) && command != "topics" {
+		// Other group members cannot act on behalf of the owner; the
+		// "/topics" command checks the permissions by itself
+		return
+	}
 	p := message.NewPrinter(c.State.Language)
 
 	if command == "start" {
-		if c.State.IsAuthorized() {
+		if c.State.TokenRevoked {
+			// The token must be renewed first, even if user has unblocked
+			// the bot
+			c.State.Activate()
+			c.State.Expectation = store.ExpectAuthToken
+			c.ShouldOK(c.saveState())
+		} else if c.State.IsAuthorized() && !c.State.IsActive() {
+			// User has unblocked the bot
+			c.State.Activate()
+			c.ShouldOK(c.saveState())
+			c.App.StartRealtime(c.ID)
+			c.ShouldSend(c.newHTMLMessage(
+				p.Sprintf("Welcome back! The bot will show you FreeFeed updates again."),
+			))
+		} else if c.State.IsAuthorized() {
 			c.ShouldSend(c.newHTMLMessage(
 				p.Sprintf("We already know each other. Use the /logout command if you want to delete all of your data or start over."),
 			))
@@ -69,6 +88,54 @@ func (c *Chat) handleCommand(update tg.Update) {
 			))
 		}
 
+	} else if command == "chat" && c.State.IsAuthorized() {
+		c.handleChatCommand(msg)
+
+	} else if command == "endchat" && c.State.IsAuthorized() {
+		c.handleEndChatCommand()
+
+	} else if command == "direct" && c.State.IsAuthorized() {
+		c.handleDirectCommand(msg.CommandArguments())
+
+	} else if command == "feed" && c.State.IsAuthorized() {
+		c.handleFeedCommand(msg.CommandArguments())
+
+	} else if command == "discussions" && c.State.IsAuthorized() {
+		c.showTimeline("filter/discussions", 0)
+
+	} else if command == "search" && c.State.IsAuthorized() {
+		c.handleSearchCommand(msg.CommandArguments())
+
+	} else if command == "watch" && c.State.IsAuthorized() {
+		c.handleWatchCommand(msg.CommandArguments())
+
+	} else if command == "watches" && c.State.IsAuthorized() {
+		c.handleWatchesCommand()
+
+	} else if command == "follow" && c.State.IsAuthorized() {
+		c.handleFollowCommand(msg.CommandArguments())
+
+	} else if command == "unfollow" && c.State.IsAuthorized() {
+		c.handleUnfollowCommand(msg.CommandArguments())
+
+	} else if command == "tracked" && c.State.IsAuthorized() {
+		c.handleTrackedCommand()
+
+	} else if command == "mutes" && c.State.IsAuthorized() {
+		c.handleMutesCommand()
+
+	} else if command == "filter" && c.State.IsAuthorized() {
+		c.handleFilterCommand(msg.CommandArguments())
+
+	} else if command == "saved" && c.State.IsAuthorized() {
+		c.handleSavedCommand()
+
+	} else if command == "requests" && c.State.IsAuthorized() {
+		c.handleRequestsCommand()
+
+	} else if command == "topics" && c.State.IsAuthorized() {
+		c.handleTopicsCommand(msg)
+
 	} else if command != "" {
 		c.ShouldSend(c.newHTMLMessage(p.Sprintf(":alien: Unknown command")))
 	}
@@ -1,10 +1,13 @@
 package chat
 
 import (
+	"strings"
+	"time"
+
+	"github.com/FreeFeed/freefeed-tg-client/frf"
 	"github.com/FreeFeed/freefeed-tg-client/store"
 	tg "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/gofrs/uuid"
-	"github.com/golang-jwt/jwt/v4"
 	"golang.org/x/text/message"
 )
 
@@ -14,16 +17,25 @@ func (c *Chat) handleMessage(update tg.Update) {
 		return
 	}
 
+	if c.State.TopicsMode && !c.isTopicsOwner(msg.From) {
+		// Messages of the other group members are not for the bot
+		return
+	}
+
 	p := message.NewPrinter(c.State.Language)
 
 	if c.State.Expectation == store.ExpectAuthToken {
 		token := msg.Text
-		_, _, err := new(jwt.Parser).ParseUnverified(token, new(jwt.RegisteredClaims))
+		expiresAt, err := parseToken(token)
 		if err != nil {
 			c.debugLog().Printf("invalid token: %v", err)
 			c.ShouldSend(c.newHTMLMessage(p.Sprintf("Looks like this token isn't valid.")))
 			return
 		}
+		if !expiresAt.IsZero() && expiresAt.Before(time.Now()) {
+			c.ShouldSend(c.newHTMLMessage(p.Sprintf("This token has already expired. Please create a new one.")))
+			return
+		}
 
 		// Delete message with the token for safety
 		c.Should(c.App.Tg().Request(tg.DeleteMessageConfig{
@@ -34,10 +46,21 @@ func (c *Chat) handleMessage(update tg.Update) {
 		statusMsg, _ := c.ShouldSend(c.newHTMLMessage(p.Sprintf("Checking your token...")))
 
 		user, err := c.frfAPIWithToken(token).GetMe()
+		var tokenInfo *frf.AppToken
+		if err == nil {
+			tokenInfo, err = loadTokenInfo(c.frfAPIWithToken(token))
+		}
 
 		if err != nil {
 			msg := tg.NewEditMessageText(c.ID, statusMsg.MessageID, p.Sprintf("Something wrong happened: %v", err))
 			c.ShouldSend(msg)
+		} else if missing := missingTokenScopes(tokenInfo); len(missing) > 0 {
+			missingScopes := strings.Join(missing, ", ")
+			msg := tg.NewEditMessageText(c.ID, statusMsg.MessageID, p.Sprintf(
+				"This token doesn't have the permissions the bot needs: %s. Please create a new token with these permissions.",
+				missingScopes,
+			))
+			c.ShouldSend(msg)
 		} else {
 			msg := tg.NewEditMessageText(c.ID, statusMsg.MessageID, c.App.Linkify(p.Sprintf(
 				"Hello, @%s!\nIt's all set. Now when the bot sees the update on FreeFeed, it will show it to you.",
@@ -49,6 +72,8 @@ func (c *Chat) handleMessage(update tg.Update) {
 			c.State.ClearExpectations()
 			c.State.UserID = user.ID
 			c.State.AccessToken = token
+			c.State.TokenRevoked = false
+			c.setTokenInfo(expiresAt, tokenInfo)
 			c.ShouldOK(c.saveState())
 
 			c.App.StartRealtime(c.ID)
@@ -83,8 +108,29 @@ func (c *Chat) handleMessage(update tg.Update) {
 		c.State.ClearExpectations()
 		c.ShouldOK(c.saveState())
 		c.App.ResumeEvents(c.ID)
+	} else if c.State.Expectation == store.ExpectDirectRecipients {
+		recipients, err := parseUserNames(msg.Text)
+		if err != nil || len(recipients) == 0 {
+			c.ShouldSend(c.newHTMLMessage(p.Sprintf("Please send the usernames of the recipients separated by spaces.")))
+			return
+		}
+		c.State.Expectation = store.ExpectDirectText
+		c.State.DirectRecipients = recipients
+		c.ShouldOK(c.saveState())
+	} else if c.State.Expectation == store.ExpectDirectText {
+		if msg.Text == "" {
+			c.ShouldSend(c.newHTMLMessage(p.Sprintf("Can not send a message without a text")))
+			return
+		}
+		recipients := c.State.DirectRecipients
+		c.State.ClearExpectations()
+		c.ShouldOK(c.saveState())
+		c.createDirect(recipients, msg.Text)
+	} else if c.State.Expectation == store.ExpectReminderTime {
+		c.handleReminderTime(msg.Text)
+	} else if c.State.Expectation == store.ExpectDirectChat && msg.ReplyToMessage == nil {
+		c.sendDirectChatComment(msg)
 	} else {
-
 		if msg.ReplyToMessage != nil {
 			eventRec, err := c.App.GetMsgRec(c.ID, msg.ReplyToMessage.MessageID)
 			c.ShouldOK(err)
@@ -112,6 +158,10 @@ func (c *Chat) handleMessage(update tg.Update) {
 			}
 		}
 
+		if c.State.TopicsMode && c.commentToTopic(msg) {
+			return
+		}
+
 		c.ShouldSend(c.newHTMLMessage(p.Sprintf(":shrug: Unknown command")))
 	}
 }
@@ -0,0 +1,165 @@
+package chat
+
+import (
+	"strings"
+
+	"github.com/FreeFeed/freefeed-tg-client/frf"
+	"github.com/FreeFeed/freefeed-tg-client/store"
+	"github.com/enescakir/emoji"
+	tg "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"golang.org/x/text/message"
+)
+
+// Moderation actions
+const (
+	modDeleteComment = "delComment"
+	modRemovePost    = "rmPost"
+	modBlockUser     = "block"
+)
+
+// adminGroups returns the groups of the event post administered by the
+// current user.
+func (c *Chat) adminGroups(event *frf.Event) []*frf.User {
+	if event.Post == nil {
+		return nil
+	}
+	var groups []*frf.User
+	for _, g := range event.Post.Groups {
+		if g.IsAdmin(c.State.UserID) {
+			groups = append(groups, g)
+		}
+	}
+	return groups
+}
+
+// moderationTarget returns the author of the event comment, or of the post if
+// there is no comment.
+func moderationTarget(event *frf.Event) *frf.User {
+	if event.Comment != nil {
+		return event.Comment.Author
+	}
+	if event.Post != nil {
+		return event.Post.Author
+	}
+	return nil
+}
+
+// moderationActions returns the callback data suffixes and the button labels
+// of the moderation actions available for the event.
+func (c *Chat) moderationActions(p *message.Printer, event *frf.Event) (actions []string, labels []string) {
+	groups := c.adminGroups(event)
+	if len(groups) == 0 {
+		return nil, nil
+	}
+
+	if event.Comment != nil && event.Comment.CreatedBy != c.State.UserID {
+		actions = append(actions, modDeleteComment)
+		labels = append(labels, p.Sprintf("Delete comment"))
+	}
+	for _, group := range groups {
+		actions = append(actions, modRemovePost+":"+group.Name)
+		labels = append(labels, p.Sprintf("Remove post from %s", group))
+	}
+	if target := moderationTarget(event); target != nil && target.ID != c.State.UserID {
+		for _, group := range groups {
+			if group.IsAdmin(target.ID) {
+				continue
+			}
+			actions = append(actions, modBlockUser+":"+group.Name)
+			labels = append(labels, p.Sprintf("Block %s in %s", target, group))
+		}
+	}
+	return actions, labels
+}
+
+func (c *Chat) postButtonsModerate(event *frf.Event) tg.InlineKeyboardMarkup {
+	p := message.NewPrinter(c.State.Language)
+
+	rows := [][]tg.InlineKeyboardButton{{
+		tg.NewInlineKeyboardButtonData(emoji.Parse(p.Sprintf(":back: Back")), doPostMore),
+	}}
+	actions, labels := c.moderationActions(p, event)
+	for i, action := range actions {
+		rows = append(rows, []tg.InlineKeyboardButton{
+			tg.NewInlineKeyboardButtonData(emoji.Parse(":cop: ")+labels[i], modActionPrefix+action),
+		})
+	}
+	return withEventRefs(tg.InlineKeyboardMarkup{InlineKeyboard: rows}, event)
+}
+
+// handleModerationCallback asks for the confirmation of the moderation action
+// or performs the confirmed one.
+func (c *Chat) handleModerationCallback(cbQuery *tg.CallbackQuery, data string, eventRec *store.SentMsgRec) {
+	p := message.NewPrinter(c.State.Language)
+	msgID := cbQuery.Message.MessageID
+	event := eventRec.Event
+
+	action, confirmed := strings.CutPrefix(data, modConfirmPrefix)
+	if !confirmed {
+		action = strings.TrimPrefix(data, modActionPrefix)
+	}
+
+	// Check the admin rights again: the actions are available only if they
+	// are in the menu for the fresh post data
+	actions, labels := c.moderationActions(p, event)
+	idx := -1
+	for i, a := range actions {
+		if a == action {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		c.ShouldSend(tg.CallbackConfig{
+			CallbackQueryID: cbQuery.ID,
+			Text:            emoji.Parse(p.Sprintf(":warning: This action is not available")),
+		})
+		return
+	}
+
+	label := labels[idx]
+
+	if !confirmed {
+		c.ShouldSend(tg.CallbackConfig{CallbackQueryID: cbQuery.ID})
+		c.ShouldSend(tg.NewEditMessageReplyMarkup(c.ID, msgID, withEventRefs(tg.NewInlineKeyboardMarkup(
+			[]tg.InlineKeyboardButton{
+				tg.NewInlineKeyboardButtonData(
+					emoji.Parse(p.Sprintf(":warning: Confirm: %s", label)),
+					modConfirmPrefix+action,
+				),
+			},
+			[]tg.InlineKeyboardButton{
+				tg.NewInlineKeyboardButtonData(emoji.Parse(p.Sprintf(":no_entry_sign: Cancel")), doModMenu),
+			},
+		), event)))
+		return
+	}
+
+	var err error
+	kind, groupName, _ := strings.Cut(action, ":")
+	switch kind {
+	case modDeleteComment:
+		err = c.frfAPI().DeleteComment(event.CommentID)
+	case modRemovePost:
+		err = c.frfAPI().RemovePostFromGroup(event.PostID, groupName)
+	case modBlockUser:
+		err = c.frfAPI().BlockUserInGroup(groupName, moderationTarget(event).Name)
+	}
+	if err != nil {
+		c.ShouldSend(tg.CallbackConfig{
+			CallbackQueryID: cbQuery.ID,
+			Text:            emoji.Parse(p.Sprintf(":warning: FreeFeed error: %v", err)),
+		})
+		return
+	}
+
+	c.ShouldSend(tg.CallbackConfig{
+		CallbackQueryID: cbQuery.ID,
+		Text:            emoji.Parse(p.Sprintf(":cop: Done: %s", label)),
+	})
+	buttons := c.postButtons(event)
+	if eventRec.Truncated {
+		buttons = c.withExpandButton(buttons, event)
+	}
+	c.ShouldSend(tg.NewEditMessageReplyMarkup(c.ID, msgID, buttons))
+}
@@ -0,0 +1,38 @@
+package chat
+
+import (
+	"testing"
+
+	"github.com/FreeFeed/freefeed-tg-client/frf"
+	"github.com/FreeFeed/freefeed-tg-client/store"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+func TestModerationActions(t *testing.T) {
+	me := uuid.Must(uuid.NewV4())
+	author := &frf.User{ID: uuid.Must(uuid.NewV4()), Name: "alice", Type: "user"}
+	commenter := &frf.User{ID: uuid.Must(uuid.NewV4()), Name: "bob", Type: "user"}
+	myGroup := &frf.User{ID: uuid.Must(uuid.NewV4()), Name: "cats", Type: "group", Administrators: []uuid.UUID{me}}
+	otherGroup := &frf.User{ID: uuid.Must(uuid.NewV4()), Name: "dogs", Type: "group"}
+
+	c := &Chat{State: &store.State{UserID: me, Language: language.English}}
+	p := message.NewPrinter(language.English)
+
+	post := &frf.Post{ID: uuid.Must(uuid.NewV4()), Author: author, Groups: []*frf.User{myGroup, otherGroup}}
+	comment := &frf.Comment{ID: uuid.Must(uuid.NewV4()), CreatedBy: commenter.ID, Author: commenter}
+
+	actions, _ := c.moderationActions(p, &frf.Event{PostID: post.ID, Post: post})
+	assert.Equal(t, []string{"rmPost:cats", "block:cats"}, actions)
+
+	actions, labels := c.moderationActions(p, &frf.Event{PostID: post.ID, Post: post, CommentID: comment.ID, Comment: comment})
+	assert.Equal(t, []string{"delComment", "rmPost:cats", "block:cats"}, actions)
+	assert.Equal(t, "Block @bob in @cats", labels[2])
+
+	// Not an admin
+	post2 := &frf.Post{ID: uuid.Must(uuid.NewV4()), Author: author, Groups: []*frf.User{otherGroup}}
+	actions, _ = c.moderationActions(p, &frf.Event{PostID: post2.ID, Post: post2})
+	assert.Empty(t, actions)
+}
@@ -0,0 +1,338 @@
+package chat
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/FreeFeed/freefeed-tg-client/frf"
+	"github.com/FreeFeed/freefeed-tg-client/store"
+	"github.com/enescakir/emoji"
+	tg "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/gofrs/uuid"
+	"golang.org/x/text/message"
+)
+
+const unmutePrefix = "unmute:"
+
+// Mute durations offered to user, zero means forever
+var muteDurations = []time.Duration{
+	24 * time.Hour,
+	7 * 24 * time.Hour,
+	30 * 24 * time.Hour,
+	0,
+}
+
+func (c *Chat) loadMutes() []store.Mute {
+	mutes, _ := c.Should(c.App.ListMutes(c.ID))
+	if mutes == nil {
+		return nil
+	}
+	return mutes.([]store.Mute)
+}
+
+// isEventMuted checks if the event matches any of the mutes. The event data
+// (e.g. of the realtime events) may not have the post author and groups, so
+// it loads the event post; the post is reused later by the filters and the
+// renderer.
+func (c *Chat) isEventMuted(mutes []store.Mute, event *frf.Event) bool {
+	if len(mutes) == 0 {
+		return false
+	}
+	now := time.Now()
+	if isMuted(event, mutes, now) {
+		return true
+	}
+	if event.Post != nil || event.LoadPost(c.frfAPI()) != nil {
+		// Loading errors will be handled in renderEvent
+		return false
+	}
+	return isMuted(event, mutes, now)
+}
+
+// isMuted checks if the event matches any of the active mutes. It uses only
+// the event data and the post, if it is already loaded.
+func isMuted(event *frf.Event, mutes []store.Mute, now time.Time) bool {
+	for _, m := range mutes {
+		if m.IsExpired(now) {
+			continue
+		}
+		switch m.Kind {
+		case store.MutePost:
+			if event.PostID == m.TargetID {
+				return true
+			}
+		case store.MuteUser:
+			if event.CreatedUserID == m.TargetID || event.PostAuthorID == m.TargetID ||
+				(event.CreatedUser != nil && event.CreatedUser.ID == m.TargetID) ||
+				(event.Post != nil && event.Post.CreatedBy == m.TargetID) {
+				return true
+			}
+		case store.MuteGroup:
+			if event.GroupID == m.TargetID || (event.Group != nil && event.Group.ID == m.TargetID) {
+				return true
+			}
+			if event.Post != nil {
+				for _, f := range event.Post.Recipients {
+					if f.OwnerID == m.TargetID {
+						return true
+					}
+				}
+				for _, g := range event.Post.Groups {
+					if g.ID == m.TargetID {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// muteTarget returns the ID and the title of the mute target of the event.
+func muteTarget(event *frf.Event, kind store.MuteKind) (uuid.UUID, string, bool) {
+	switch kind {
+	case store.MutePost:
+		if event.Post != nil {
+			return event.PostID, event.Post.Digest(), true
+		}
+	case store.MuteUser:
+		if event.CreatedUser != nil {
+			return event.CreatedUser.ID, event.CreatedUser.String(), true
+		}
+		if event.Post != nil && event.Post.Author != nil {
+			return event.Post.Author.ID, event.Post.Author.String(), true
+		}
+	case store.MuteGroup:
+		if event.Group != nil {
+			return event.Group.ID, event.Group.String(), true
+		}
+		if event.Post != nil && len(event.Post.Groups) > 0 {
+			// The first group of the post, the others can be muted
+			// from their own posts
+			return event.Post.Groups[0].ID, event.Post.Groups[0].String(), true
+		}
+	}
+	return uuid.Nil, "", false
+}
+
+func (c *Chat) postButtonsMute(event *frf.Event) tg.InlineKeyboardMarkup {
+	p := message.NewPrinter(c.State.Language)
+
+	rows := [][]tg.InlineKeyboardButton{{
+		tg.NewInlineKeyboardButtonData(emoji.Parse(p.Sprintf(":back: Back")), doPostMore),
+	}}
+	if _, _, ok := muteTarget(event, store.MutePost); ok {
+		rows = append(rows, []tg.InlineKeyboardButton{
+			tg.NewInlineKeyboardButtonData(
+				emoji.Parse(p.Sprintf(":mute: Mute this post")),
+				muteData(store.MutePost),
+			),
+		})
+	}
+	if _, title, ok := muteTarget(event, store.MuteUser); ok {
+		rows = append(rows, []tg.InlineKeyboardButton{
+			tg.NewInlineKeyboardButtonData(
+				emoji.Parse(p.Sprintf(":mute: Mute %s", title)),
+				muteData(store.MuteUser),
+			),
+		})
+	}
+	if _, title, ok := muteTarget(event, store.MuteGroup); ok {
+		rows = append(rows, []tg.InlineKeyboardButton{
+			tg.NewInlineKeyboardButtonData(
+				emoji.Parse(p.Sprintf(":mute: Mute group %s", title)),
+				muteData(store.MuteGroup),
+			),
+		})
+	}
+	return withEventRefs(tg.InlineKeyboardMarkup{InlineKeyboard: rows}, event)
+}
+
+func (c *Chat) muteDurationButtons(kind store.MuteKind, event *frf.Event) tg.InlineKeyboardMarkup {
+	p := message.NewPrinter(c.State.Language)
+
+	var row []tg.InlineKeyboardButton
+	for _, d := range muteDurations {
+		row = append(row, tg.NewInlineKeyboardButtonData(
+			muteDurationLabel(p, d),
+			muteData(kind)+":"+strconv.Itoa(int(d/time.Hour)),
+		))
+	}
+	return withEventRefs(tg.NewInlineKeyboardMarkup(
+		[]tg.InlineKeyboardButton{
+			tg.NewInlineKeyboardButtonData(emoji.Parse(p.Sprintf(":back: Back")), doMuteMenu),
+		},
+		row,
+	), event)
+}
+
+func muteDurationLabel(p *message.Printer, d time.Duration) string {
+	switch d {
+	case 0:
+		return p.Sprintf("Forever")
+	case 24 * time.Hour:
+		return p.Sprintf("1 day")
+	case 7 * 24 * time.Hour:
+		return p.Sprintf("1 week")
+	case 30 * 24 * time.Hour:
+		return p.Sprintf("1 month")
+	}
+	return d.String()
+}
+
+func muteData(kind store.MuteKind) string {
+	return muteActionPrefix + string(kind)
+}
+
+// parseMuteData parses the "e:mute:kind[:hours]" callback data. The hasDuration
+// is false when user hasn't chosen the duration yet.
+func parseMuteData(data string) (kind store.MuteKind, duration time.Duration, hasDuration bool, ok bool) {
+	data, ok = strings.CutPrefix(data, muteActionPrefix)
+	if !ok {
+		return
+	}
+	kindStr, hoursStr, hasDuration := strings.Cut(data, ":")
+	kind = store.MuteKind(kindStr)
+	if kind != store.MutePost && kind != store.MuteUser && kind != store.MuteGroup {
+		return "", 0, false, false
+	}
+	if hasDuration {
+		hours, err := strconv.Atoi(hoursStr)
+		if err != nil || hours < 0 {
+			return "", 0, false, false
+		}
+		duration = time.Duration(hours) * time.Hour
+	}
+	return kind, duration, hasDuration, true
+}
+
+// handleMuteCallback shows the mute duration choice or mutes the target of the
+// event.
+func (c *Chat) handleMuteCallback(cbQuery *tg.CallbackQuery, data string, eventRec *store.SentMsgRec) {
+	p := message.NewPrinter(c.State.Language)
+	msgID := cbQuery.Message.MessageID
+	event := eventRec.Event
+
+	kind, duration, hasDuration, ok := parseMuteData(data)
+	targetID, title, hasTarget := muteTarget(event, kind)
+	if !ok || !hasTarget {
+		c.ShouldSend(tg.CallbackConfig{
+			CallbackQueryID: cbQuery.ID,
+			Text:            emoji.Parse(p.Sprintf(":alien: Unknown command %v", data)),
+		})
+		return
+	}
+
+	if !hasDuration {
+		c.ShouldSend(tg.CallbackConfig{CallbackQueryID: cbQuery.ID})
+		c.ShouldSend(tg.NewEditMessageReplyMarkup(c.ID, msgID, c.muteDurationButtons(kind, event)))
+		return
+	}
+
+	mute := store.Mute{
+		ID:       uuid.Must(uuid.NewV4()),
+		Kind:     kind,
+		TargetID: targetID,
+		Title:    title,
+	}
+	if duration > 0 {
+		mute.Until = time.Now().Add(duration)
+	}
+	if err := c.App.AddMute(c.ID, mute); err != nil {
+		c.errorLog().Print(err)
+		c.ShouldSend(tg.CallbackConfig{
+			CallbackQueryID: cbQuery.ID,
+			Text:            emoji.Parse(p.Sprintf(":warning: Error: %v", err)),
+		})
+		return
+	}
+
+	c.ShouldSend(tg.CallbackConfig{
+		CallbackQueryID: cbQuery.ID,
+		Text:            emoji.Parse(p.Sprintf(":mute: Muted. Use /mutes to manage the muted items.")),
+	})
+	buttons := c.postButtons(event)
+	if eventRec.Truncated {
+		buttons = c.withExpandButton(buttons, event)
+	}
+	c.ShouldSend(tg.NewEditMessageReplyMarkup(c.ID, msgID, buttons))
+}
+
+// handleMutesCommand lists the active mutes with the buttons to remove them.
+func (c *Chat) handleMutesCommand() {
+	text, markup := c.renderMutesList()
+	msg := c.newRawHTMLMessage(text)
+	msg.ReplyMarkup = markup
+	c.ShouldSend(msg)
+}
+
+// renderMutesList renders the list of active mutes and the buttons to remove
+// them.
+func (c *Chat) renderMutesList() (string, *tg.InlineKeyboardMarkup) {
+	p := message.NewPrinter(c.State.Language)
+
+	mutes, err := c.App.ListMutes(c.ID)
+	if err != nil {
+		c.errorLog().Print(err)
+		return c.App.Linkify(p.Sprintf("Cannot load the muted items: %v", err)), nil
+	}
+
+	now := time.Now()
+	var (
+		lines []string
+		rows  [][]tg.InlineKeyboardButton
+	)
+	for _, m := range mutes {
+		if m.IsExpired(now) {
+			continue
+		}
+		number := len(lines) + 1
+		lines = append(lines, fmt.Sprintf("%d. %s", number, c.App.Linkify(c.muteDescription(p, m))))
+		rows = append(rows, []tg.InlineKeyboardButton{
+			tg.NewInlineKeyboardButtonData(
+				emoji.Parse(p.Sprintf(":sound: Unmute #%d", number)),
+				unmutePrefix+m.ID.String(),
+			),
+		})
+	}
+	if len(lines) == 0 {
+		return c.App.Linkify(p.Sprintf("You have no muted posts, users or groups.")), nil
+	}
+
+	text := c.App.Linkify(p.Sprintf("Muted posts, users and groups:")) + "\n\n" + strings.Join(lines, "\n")
+	return text, &tg.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+func (c *Chat) muteDescription(p *message.Printer, m store.Mute) string {
+	var text string
+	switch m.Kind {
+	case store.MutePost:
+		digest := c.App.ContentOf(m.Title)
+		text = p.Sprintf("post \"%s\"", digest)
+	case store.MuteGroup:
+		text = p.Sprintf("group %s", m.Title)
+	default:
+		text = m.Title
+	}
+	if !m.Until.IsZero() {
+		until := m.Until.UTC().Format("2006-01-02 15:04 UTC")
+		text += ", " + p.Sprintf("until %s", until)
+	}
+	return text
+}
+
+// handleUnmuteCallback removes the mute and updates the list.
+func (c *Chat) handleUnmuteCallback(cbQuery *tg.CallbackQuery, muteID uuid.UUID) {
+	p := message.NewPrinter(c.State.Language)
+
+	text := p.Sprintf("Unmuted")
+	if err := c.App.DeleteMute(c.ID, muteID); err != nil {
+		text = p.Sprintf("Cannot unmute: %v", err)
+	}
+	c.ShouldSend(tg.CallbackConfig{CallbackQueryID: cbQuery.ID, Text: text})
+
+	newText, markup := c.renderMutesList()
+	c.editListMessage(cbQuery.Message.MessageID, newText, markup)
+}
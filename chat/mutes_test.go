@@ -0,0 +1,143 @@
+package chat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FreeFeed/freefeed-tg-client/frf"
+	"github.com/FreeFeed/freefeed-tg-client/store"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsMuted(t *testing.T) {
+	now := time.Now()
+	postID := uuid.Must(uuid.NewV4())
+	userID := uuid.Must(uuid.NewV4())
+	groupID := uuid.Must(uuid.NewV4())
+
+	tests := []struct {
+		name  string
+		event *frf.Event
+		mute  store.Mute
+		muted bool
+	}{
+		{
+			"post",
+			&frf.Event{PostID: postID},
+			store.Mute{Kind: store.MutePost, TargetID: postID},
+			true,
+		},
+		{
+			"event creator",
+			&frf.Event{CreatedUserID: userID},
+			store.Mute{Kind: store.MuteUser, TargetID: userID},
+			true,
+		},
+		{
+			"post author",
+			&frf.Event{PostID: postID, Post: &frf.Post{ID: postID, CreatedBy: userID}},
+			store.Mute{Kind: store.MuteUser, TargetID: userID},
+			true,
+		},
+		{
+			"group of event",
+			&frf.Event{GroupID: groupID},
+			store.Mute{Kind: store.MuteGroup, TargetID: groupID},
+			true,
+		},
+		{
+			"group of post",
+			&frf.Event{PostID: postID, Post: &frf.Post{ID: postID, Recipients: []frf.Feed{{OwnerID: groupID}}}},
+			store.Mute{Kind: store.MuteGroup, TargetID: groupID},
+			true,
+		},
+		{
+			"post author of realtime comment",
+			&frf.Event{
+				Type:          "__comment:new",
+				PostID:        postID,
+				CreatedUserID: uuid.Must(uuid.NewV4()),
+				Post:          &frf.Post{ID: postID, CreatedBy: userID},
+			},
+			store.Mute{Kind: store.MuteUser, TargetID: userID},
+			true,
+		},
+		{
+			"group of realtime comment post",
+			&frf.Event{
+				Type:          "__comment:new",
+				PostID:        postID,
+				CreatedUserID: uuid.Must(uuid.NewV4()),
+				Post:          &frf.Post{ID: postID, Groups: []*frf.User{{ID: groupID}}},
+			},
+			store.Mute{Kind: store.MuteGroup, TargetID: groupID},
+			true,
+		},
+		{
+			"realtime comment before post loading",
+			&frf.Event{Type: "__comment:new", PostID: postID, CreatedUserID: uuid.Must(uuid.NewV4())},
+			store.Mute{Kind: store.MuteGroup, TargetID: groupID},
+			false,
+		},
+		{
+			"expired",
+			&frf.Event{PostID: postID},
+			store.Mute{Kind: store.MutePost, TargetID: postID, Until: now.Add(-time.Minute)},
+			false,
+		},
+		{
+			"other user",
+			&frf.Event{PostID: postID, CreatedUserID: uuid.Must(uuid.NewV4())},
+			store.Mute{Kind: store.MuteUser, TargetID: userID},
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.muted, isMuted(tt.event, []store.Mute{tt.mute}, now))
+		})
+	}
+}
+
+func TestParseMuteData(t *testing.T) {
+	kind, duration, hasDuration, ok := parseMuteData("e:mute:user")
+	assert.True(t, ok)
+	assert.Equal(t, store.MuteUser, kind)
+	assert.False(t, hasDuration)
+
+	kind, duration, hasDuration, ok = parseMuteData("e:mute:group:168")
+	assert.True(t, ok)
+	assert.Equal(t, store.MuteGroup, kind)
+	assert.True(t, hasDuration)
+	assert.Equal(t, 7*24*time.Hour, duration)
+
+	_, duration, hasDuration, ok = parseMuteData("e:mute:post:0")
+	assert.True(t, ok)
+	assert.True(t, hasDuration)
+	assert.Zero(t, duration)
+
+	_, _, _, ok = parseMuteData("e:mute:feed")
+	assert.False(t, ok)
+	_, _, _, ok = parseMuteData("e:mute:post:x")
+	assert.False(t, ok)
+}
+
+func TestMuteTargetOfGroupPost(t *testing.T) {
+	author := &frf.User{ID: uuid.Must(uuid.NewV4()), Name: "alice"}
+	group := &frf.User{ID: uuid.Must(uuid.NewV4()), Name: "cats", Type: "group"}
+	event := &frf.Event{
+		Type:   "__comment:new",
+		PostID: uuid.Must(uuid.NewV4()),
+		Post:   &frf.Post{Author: author, Groups: []*frf.User{group}},
+	}
+
+	id, _, ok := muteTarget(event, store.MuteUser)
+	assert.True(t, ok)
+	assert.Equal(t, author.ID, id)
+
+	id, _, ok = muteTarget(event, store.MuteGroup)
+	assert.True(t, ok)
+	assert.Equal(t, group.ID, id)
+}
@@ -0,0 +1,108 @@
+package chat
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/FreeFeed/freefeed-tg-client/frf"
+	"github.com/FreeFeed/freefeed-tg-client/store"
+	"golang.org/x/text/message"
+)
+
+var userNameRe = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// handleDirectCommand handles the "/direct @user1 @user2 text" command. If
+// recipients or text are not specified, it asks for them.
+func (c *Chat) handleDirectCommand(args string) {
+	p := message.NewPrinter(c.State.Language)
+
+	recipients, text, err := parseDirectArgs(args)
+	if err != nil {
+		c.ShouldSend(c.newHTMLMessage(p.Sprintf("Invalid recipient: %v", err)))
+		return
+	}
+
+	if text != "" {
+		c.createDirect(recipients, text)
+		return
+	}
+
+	c.State.ClearExpectations()
+	if len(recipients) == 0 {
+		c.State.Expectation = store.ExpectDirectRecipients
+	} else {
+		c.State.Expectation = store.ExpectDirectText
+		c.State.DirectRecipients = recipients
+	}
+	c.ShouldOK(c.saveState())
+}
+
+// createDirect creates the direct message and sends the confirmation. Replies
+// to the confirmation become comments to the direct message.
+func (c *Chat) createDirect(recipients []string, text string) {
+	p := message.NewPrinter(c.State.Language)
+
+	if len(recipients) == 0 {
+		c.ShouldSend(c.newHTMLMessage(p.Sprintf("Usage: /direct @username text")))
+		return
+	}
+
+	post, err := c.frfAPI().CreatePost(recipients, text)
+	if err != nil {
+		c.ShouldSend(c.newHTMLMessage(p.Sprintf(":warning: Cannot send the direct message: %v", err)))
+		return
+	}
+
+	recipientsList := formatUserNames(recipients)
+	event := &frf.Event{PostID: post.ID, Post: post}
+	msg := c.newHTMLMessage(p.Sprintf(":e-mail: Direct message to %s is sent.", recipientsList))
+	msg.ReplyMarkup = c.postButtons(event)
+	sent, err := c.ShouldSendAndSave(msg, store.SentMsgRec{Event: event})
+	if err == nil {
+		// The further comments will be threaded under this message
+		c.ShouldOK(c.App.PutPostThread(c.ID, post.ID, sent.MessageID))
+	}
+}
+
+// parseDirectArgs splits the command arguments into the leading @usernames and
+// the message text.
+func parseDirectArgs(args string) (recipients []string, text string, err error) {
+	text = strings.TrimSpace(args)
+	for strings.HasPrefix(text, "@") {
+		end := strings.IndexFunc(text, unicode.IsSpace)
+		if end < 0 {
+			end = len(text)
+		}
+		name := strings.ToLower(strings.TrimRight(text[1:end], ","))
+		if !userNameRe.MatchString(name) {
+			return nil, "", fmt.Errorf("%q", text[:end])
+		}
+		recipients = append(recipients, name)
+		text = strings.TrimSpace(text[end:])
+	}
+	return recipients, text, nil
+}
+
+// parseUserNames parses the list of usernames separated by spaces or commas,
+// with or without the '@' prefix.
+func parseUserNames(text string) ([]string, error) {
+	var names []string
+	for _, word := range strings.FieldsFunc(text, func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+		name := strings.ToLower(strings.TrimPrefix(word, "@"))
+		if !userNameRe.MatchString(name) {
+			return nil, fmt.Errorf("%q", word)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+func formatUserNames(names []string) string {
+	mentions := make([]string, len(names))
+	for i, name := range names {
+		mentions[i] = "@" + name
+	}
+	return strings.Join(mentions, ", ")
+}
@@ -0,0 +1,36 @@
+package chat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDirectArgs(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       string
+		recipients []string
+		text       string
+		isError    bool
+	}{
+		{"empty", "", nil, "", false},
+		{"recipients only", "@alice @Bob", []string{"alice", "bob"}, "", false},
+		{"recipients and text", "@alice, @bob Hello,\nworld!", []string{"alice", "bob"}, "Hello,\nworld!", false},
+		{"text only", "Hello @alice", nil, "Hello @alice", false},
+		{"invalid recipient", "@alice @b_b Hello", nil, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recipients, text, err := parseDirectArgs(tt.args)
+			if tt.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.recipients, recipients)
+			assert.Equal(t, tt.text, text)
+		})
+	}
+}
@@ -7,20 +7,35 @@ import (
 	"golang.org/x/text/message"
 )
 
+// printExpectationMessage prints the prompt for the current expectation. It
+// prints it only once per chat update.
 func (c *Chat) printExpectationMessage() {
+	if c.expectationPrinted {
+		return
+	}
+	c.expectationPrinted = true
+
 	p := message.NewPrinter(c.State.Language)
 
 	if c.State.Expectation == store.ExpectAuthToken {
-		createTokenURL := "https://" + c.App.FreeFeedAPI().HostName +
-			"/settings/app-tokens/create?title=FreeFeed%20Telegram%20bot&scopes=read-my-info%20read-realtime%20manage-notifications%20manage-posts%20manage-subscription-requests%20manage-groups"
+		btnText := p.Sprintf(":key: Create token")
+		if c.State.IsAuthorized() {
+			btnText = p.Sprintf(":key: Create new token")
+		}
+
+		row := []tg.InlineKeyboardButton{
+			tg.NewInlineKeyboardButtonURL(emoji.Parse(btnText), c.createTokenURL()),
+		}
+		if c.State.IsAuthorized() && !c.State.TokenRevoked {
+			// User is renewing the valid token, so they can change their mind
+			row = append(row, tg.NewInlineKeyboardButtonData(
+				emoji.Parse(p.Sprintf(":no_entry_sign: Cancel")),
+				"cancel",
+			))
+		}
 
 		msg := c.newHTMLMessage(p.Sprintf("Please create the access token and send it to the bot:"))
-		msg.ReplyMarkup = tg.NewInlineKeyboardMarkup([]tg.InlineKeyboardButton{
-			tg.NewInlineKeyboardButtonURL(emoji.Parse(
-				p.Sprintf(":key: Create token")),
-				createTokenURL,
-			),
-		})
+		msg.ReplyMarkup = tg.NewInlineKeyboardMarkup(row)
 		c.ShouldSend(msg)
 
 	} else if c.State.Expectation == store.ExpectLanguage {
@@ -31,6 +46,32 @@ func (c *Chat) printExpectationMessage() {
 		})
 		c.ShouldSend(msg)
 
+	} else if c.State.Expectation == store.ExpectDirectRecipients || c.State.Expectation == store.ExpectDirectText {
+		text := p.Sprintf("Who should receive the direct message? Send the usernames separated by spaces.")
+		if c.State.Expectation == store.ExpectDirectText {
+			recipientsList := formatUserNames(c.State.DirectRecipients)
+			text = p.Sprintf("Enter the text of the direct message to %s.", recipientsList)
+		}
+
+		msg := c.newHTMLMessage(text)
+		msg.ReplyMarkup = tg.NewInlineKeyboardMarkup([]tg.InlineKeyboardButton{
+			tg.NewInlineKeyboardButtonData(
+				emoji.Parse(p.Sprintf(":no_entry_sign: Cancel")),
+				"cancel",
+			),
+		})
+		c.ShouldSend(msg)
+
+	} else if c.State.Expectation == store.ExpectReminderTime {
+		msg := c.newHTMLMessage(p.Sprintf("When should I remind you? Send the delay, for example 30m, 2h or 1d12h."))
+		msg.ReplyMarkup = tg.NewInlineKeyboardMarkup([]tg.InlineKeyboardButton{
+			tg.NewInlineKeyboardButtonData(
+				emoji.Parse(p.Sprintf(":no_entry_sign: Cancel")),
+				"cancel",
+			),
+		})
+		c.ShouldSend(msg)
+
 	} else if c.State.Expectation == store.ExpectComment {
 		text := p.Sprintf("Enter your comment text.")
 		if c.State.CommentPrefix != "" {
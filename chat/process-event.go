@@ -14,21 +14,77 @@ func (c *Chat) ProcessEvents(events []*frf.Event) {
 	c.debugLog().Printf("Start ProcessEvents for %d events", len(events))
 	defer c.debugLog().Printf("Finish ProcessEvents for %d events", len(events))
 
+	if c.State.TokenRevoked {
+		c.debugLog().Printf("Access token is revoked, skipping events")
+		return
+	}
+
 	c.debugLog().Printf("Checking paused state...")
 	isPaused := c.App.EventsPaused(c.ID)
 	c.debugLog().Printf("Result: %v", isPaused)
 
+	var (
+		filters []store.Filter
+		mutes   []store.Mute
+	)
+	if !isPaused {
+		filters = c.loadFilters()
+		mutes = c.loadMutes()
+	}
+
 	for _, event := range events {
 		c.debugLog().Printf("ProcessEvents for %s", event.Type)
 		if isPaused {
 			c.debugLog().Printf("Paused, adding %s to event queue", event.Type)
 			data, _ := c.Should(json.Marshal(event))
 			c.ShouldOK(c.App.AddToQueue(c.ID, data.([]byte)))
-		} else if msg := c.renderEvent(event); msg != nil {
+			continue
+		}
+
+		if c.isEventMuted(mutes, event) {
+			c.debugLog().Printf("Event %s is muted, skipping", event.Type)
+			continue
+		}
+		matches, ok := c.applyFilters(filters, event)
+		if !ok {
+			c.debugLog().Printf("Event %s is filtered out", event.Type)
+			continue
+		}
+		if msg := c.renderEvent(event); msg != nil {
 			c.debugLog().Printf("Sending %s to user", event.Type)
-			c.ShouldSendAndSave(msg, store.SentMsgRec{Event: event})
+			c.withFilterMatches(msg, matches)
+			c.sendEventMessage(msg, event)
+		}
+	}
+}
+
+// sendEventMessage sends the rendered event message. Messages related to the
+// same post are sent as replies to the first message about this post, so
+// Telegram groups them into the thread. In the topics mode they are sent to
+// the post topic instead.
+func (c *Chat) sendEventMessage(msg tg.Chattable, event *frf.Event) {
+	rest := c.fitMessage(msg, event)
+	rec := store.SentMsgRec{Event: event, Truncated: len(rest) > 0, Rest: rest}
+
+	m, isMessage := msg.(*tg.MessageConfig)
+	if c.State.TopicsMode && isMessage && event.PostID != uuid.Nil && c.sendToPostTopic(m, rec) {
+		return
+	}
+
+	threadID := 0
+	if isMessage && event.PostID != uuid.Nil && !c.isDirectChatEvent(event) {
+		threadID, _ = c.App.GetPostThread(c.ID, event.PostID)
+		if threadID != 0 {
+			m.ReplyToMessageID = threadID
+			// The thread message may be deleted by user
+			m.AllowSendingWithoutReply = true
 		}
 	}
+
+	sent, err := c.ShouldSendAndSave(msg, rec)
+	if err == nil && isMessage && event.PostID != uuid.Nil && !c.isDirectChatEvent(event) && threadID == 0 {
+		c.ShouldOK(c.App.PutPostThread(c.ID, event.PostID, sent.MessageID))
+	}
 }
 
 func (c *Chat) renderEvent(event *frf.Event) tg.Chattable {
@@ -42,7 +98,10 @@ func (c *Chat) renderEvent(event *frf.Event) tg.Chattable {
 	}
 
 	p := message.NewPrinter(c.State.Language)
-	event.LoadPost(c.frfAPI())
+	if err := event.LoadPost(c.frfAPI()); frf.IsUnauthorized(err) {
+		// User is already notified about the revoked token
+		return nil
+	}
 
 	switch event.Type {
 	// ===========================
@@ -180,6 +239,9 @@ func (c *Chat) renderEvent(event *frf.Event) tg.Chattable {
 			// We will receive this with post subscription
 			return nil
 		}
+		if c.isDirectChatEvent(event) {
+			return c.renderDirectChatComment(event)
+		}
 
 		headText := p.Sprintf(
 			":e-mail: New comment was posted by %s to the direct message \"%s\":",
@@ -195,6 +257,9 @@ func (c *Chat) renderEvent(event *frf.Event) tg.Chattable {
 			// Hidden comment, don't do anything
 			return nil
 		}
+		if c.isDirectChatEvent(event) {
+			return c.renderDirectChatComment(event)
+		}
 		headText := p.Sprintf(
 			":e-mail: New comment was posted by %s to the post \"%s\":",
 			event.CreatedUser,
@@ -202,6 +267,27 @@ func (c *Chat) renderEvent(event *frf.Event) tg.Chattable {
 		)
 		return c.withCommentBody(c.newHTMLMessage(headText), event)
 
+	case "__post:new":
+		headText := p.Sprintf(":new: New post by %s:", event.CreatedUser)
+		return c.withPostBody(c.newHTMLMessage(headText), event)
+
+	case timelinePostEvent:
+		if event.Post == nil {
+			return nil
+		}
+		return c.renderTimelinePost(event)
+
+	case watchPostEvent:
+		if event.Post == nil {
+			return nil
+		}
+		query := event.WatchQuery
+		headText := p.Sprintf(":mag: New post matching \"%s\":", query)
+		if event.CreatedUser != nil {
+			headText = p.Sprintf(":mag: New post by %s matching \"%s\":", event.CreatedUser, query)
+		}
+		return c.withPostBody(c.newHTMLMessage(headText), event)
+
 	// ===========================
 	// Incoming subscription requests
 	// ===========================
@@ -433,7 +519,49 @@ func (c *Chat) withCommentBody(msg *tg.MessageConfig, event *frf.Event) (out tg.
 		}
 	}
 
-	msg.Text += bodySeparator + c.App.ContentOf(c.App.Linkify(comment.Body))
+	msg.Text += bodySeparator + c.App.ContentOf(c.App.LinkifyComment(comment.Body, event.Post, comment.ID))
 	msg.ReplyMarkup = c.postButtons(event)
 	return msg
 }
+
+// fitMessage truncates the message text if it exceeds the Telegram limit and
+// adds the "Expand" button to the message. It returns the rest of the text,
+// split to parts, or nil if the text was not truncated.
+func (c *Chat) fitMessage(msg tg.Chattable, event *frf.Event) []string {
+	m, ok := msg.(*tg.MessageConfig)
+	if !ok || textLength(m.Text) <= maxMessageLength {
+		return nil
+	}
+
+	parts := splitHTML(m.Text, maxMessageLength-textLength(truncationMark))
+	m.Text = parts[0] + truncationMark
+
+	markup, _ := m.ReplyMarkup.(tg.InlineKeyboardMarkup)
+	m.ReplyMarkup = c.withExpandButton(markup, event)
+	return parts[1:]
+}
+
+// expandMessage sends the rest of the truncated message text in the follow-up
+// messages.
+func (c *Chat) expandMessage(messageID int, eventRec store.SentMsgRec) {
+	event := eventRec.Event
+	parts := eventRec.Rest
+	if len(parts) == 0 {
+		// The record has no text (it is legacy or restored from the callback
+		// data), so render the event again
+		msg, ok := c.renderEvent(event).(*tg.MessageConfig)
+		if !ok {
+			return
+		}
+		// The header must be the same as in the original message
+		matches, _ := c.applyFilters(c.loadFilters(), event)
+		c.withFilterMatches(msg, matches)
+		parts = splitHTML(msg.Text, maxMessageLength-textLength(truncationMark))[1:]
+	}
+
+	for _, part := range parts {
+		partMsg := c.newRawHTMLMessage(part)
+		partMsg.ReplyToMessageID = messageID
+		c.ShouldSendAndSave(partMsg, store.SentMsgRec{Event: event, ReplyToID: messageID})
+	}
+}
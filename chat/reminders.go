@@ -0,0 +1,231 @@
+package chat
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/FreeFeed/freefeed-tg-client/frf"
+	"github.com/FreeFeed/freefeed-tg-client/store"
+	"github.com/enescakir/emoji"
+	tg "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/gofrs/uuid"
+	"golang.org/x/text/message"
+)
+
+const (
+	// Maximum number of pending reminders per chat
+	maxReminders = 50
+	// Maximum delay of the custom reminder
+	maxReminderDelay = 365 * 24 * time.Hour
+)
+
+const unremindPrefix = "unremind:"
+
+func (c *Chat) postButtonsRemind(event *frf.Event) tg.InlineKeyboardMarkup {
+	p := message.NewPrinter(c.State.Language)
+
+	return withEventRefs(tg.NewInlineKeyboardMarkup(
+		[]tg.InlineKeyboardButton{
+			tg.NewInlineKeyboardButtonData(emoji.Parse(p.Sprintf(":back: Back")), doPostMore),
+		},
+		[]tg.InlineKeyboardButton{
+			tg.NewInlineKeyboardButtonData(p.Sprintf("In 1 hour"), remindActionPrefix+"1h"),
+			tg.NewInlineKeyboardButtonData(p.Sprintf("In 3 hours"), remindActionPrefix+"3h"),
+		},
+		[]tg.InlineKeyboardButton{
+			tg.NewInlineKeyboardButtonData(p.Sprintf("Tomorrow"), remindActionPrefix+"tomorrow"),
+			tg.NewInlineKeyboardButtonData(p.Sprintf("Custom time…"), remindActionPrefix+"custom"),
+		},
+	), event)
+}
+
+// handleRemindCallback creates the reminder with the preset delay or asks
+// user for the custom one.
+func (c *Chat) handleRemindCallback(cbQuery *tg.CallbackQuery, data string, eventRec *store.SentMsgRec) {
+	p := message.NewPrinter(c.State.Language)
+	msgID := cbQuery.Message.MessageID
+	choice := strings.TrimPrefix(data, remindActionPrefix)
+
+	if choice == "custom" {
+		c.State.ClearExpectations()
+		c.State.Expectation = store.ExpectReminderTime
+		c.State.ReactToMessageID = msgID
+		c.ShouldOK(c.saveState())
+		c.ShouldSend(tg.CallbackConfig{CallbackQueryID: cbQuery.ID})
+		return
+	}
+
+	delay, ok := reminderPresetDelay(choice)
+	if ok {
+		c.ShouldSend(tg.CallbackConfig{CallbackQueryID: cbQuery.ID})
+		c.addReminder(eventRec.Event, msgID, time.Now().Add(delay))
+
+		buttons := c.postButtons(eventRec.Event)
+		if eventRec.Truncated {
+			buttons = c.withExpandButton(buttons, eventRec.Event)
+		}
+		c.ShouldSend(tg.NewEditMessageReplyMarkup(c.ID, msgID, buttons))
+		return
+	}
+
+	c.ShouldSend(tg.CallbackConfig{
+		CallbackQueryID: cbQuery.ID,
+		Text:            emoji.Parse(p.Sprintf(":alien: Unknown command %v", data)),
+	})
+}
+
+// reminderPresetDelay returns the delay of the menu choice.
+func reminderPresetDelay(choice string) (time.Duration, bool) {
+	switch choice {
+	case "1h":
+		return time.Hour, true
+	case "3h":
+		return 3 * time.Hour, true
+	case "tomorrow":
+		return 24 * time.Hour, true
+	}
+	return 0, false
+}
+
+// handleReminderTime handles the custom reminder delay sent by user.
+func (c *Chat) handleReminderTime(text string) {
+	p := message.NewPrinter(c.State.Language)
+
+	delay, err := parseReminderDelay(text)
+	if err != nil {
+		c.ShouldSend(c.newHTMLMessage(p.Sprintf("Please send the delay like 30m, 2h or 1d12h.")))
+		return
+	}
+
+	eventRec, err := c.App.GetMsgRec(c.ID, c.State.ReactToMessageID)
+	c.State.ClearExpectations()
+	c.ShouldOK(c.saveState())
+	if err != nil {
+		c.ShouldSend(c.newHTMLMessage(p.Sprintf("Cannot create reminder: %v", err)))
+		return
+	}
+
+	c.addReminder(eventRec.Event, eventRec.MessageID, time.Now().Add(delay))
+}
+
+var reminderDelayRe = regexp.MustCompile(`^(?:(\d+)d)?(?:(\d+)h)?(?:(\d+)m)?$`)
+
+// parseReminderDelay parses the delay in form of "1d2h30m", every part is
+// optional.
+func parseReminderDelay(text string) (time.Duration, error) {
+	text = strings.ToLower(strings.Join(strings.Fields(text), ""))
+	m := reminderDelayRe.FindStringSubmatch(text)
+	if text == "" || m == nil {
+		return 0, fmt.Errorf("invalid delay: %q", text)
+	}
+
+	var delay time.Duration
+	for i, unit := range []time.Duration{24 * time.Hour, time.Hour, time.Minute} {
+		if m[i+1] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(m[i+1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid delay: %q", text)
+		}
+		delay += time.Duration(n) * unit
+		if delay > maxReminderDelay {
+			return 0, fmt.Errorf("delay is too long")
+		}
+	}
+	if delay <= 0 {
+		return 0, fmt.Errorf("delay must be positive")
+	}
+	return delay, nil
+}
+
+// addReminder saves the reminder and sends the message with the button to
+// cancel it.
+func (c *Chat) addReminder(event *frf.Event, replyTo int, fireAt time.Time) {
+	p := message.NewPrinter(c.State.Language)
+
+	reminders, err := c.App.ListReminders(c.ID)
+	if c.ShouldOK(err) != nil {
+		return
+	}
+	if len(reminders) >= maxReminders {
+		c.ShouldSend(c.newHTMLMessage(p.Sprintf(
+			"You cannot have more than %d pending reminders.",
+			maxReminders,
+		)))
+		return
+	}
+
+	reminder := store.Reminder{ID: uuid.Must(uuid.NewV4()), FireAt: fireAt, Event: event}
+
+	fireTime := fireAt.UTC().Format("2006-01-02 15:04 UTC")
+	msg := c.newHTMLMessage(p.Sprintf(":alarm_clock: I will remind you about this at %s.", fireTime))
+	msg.ReplyToMessageID = replyTo
+	msg.AllowSendingWithoutReply = true
+	msg.ReplyMarkup = tg.NewInlineKeyboardMarkup([]tg.InlineKeyboardButton{
+		tg.NewInlineKeyboardButtonData(
+			emoji.Parse(p.Sprintf(":no_entry_sign: Cancel reminder")),
+			unremindPrefix+reminder.ID.String(),
+		),
+	})
+	sent, err := c.ShouldSend(msg)
+	if err != nil {
+		return
+	}
+	reminder.MessageID = sent.MessageID
+
+	if err := c.App.AddReminder(c.ID, reminder); err != nil {
+		c.errorLog().Print(err)
+		c.ShouldSend(tg.NewEditMessageText(c.ID, sent.MessageID, p.Sprintf("Cannot create reminder: %v", err)))
+	}
+}
+
+// handleUnremindCallback cancels the reminder.
+func (c *Chat) handleUnremindCallback(cbQuery *tg.CallbackQuery, reminderID uuid.UUID) {
+	p := message.NewPrinter(c.State.Language)
+
+	text := p.Sprintf("Reminder is cancelled")
+	if err := c.App.DeleteReminder(c.ID, reminderID); err != nil {
+		text = p.Sprintf("Cannot cancel reminder: %v", err)
+		c.ShouldSend(tg.CallbackConfig{CallbackQueryID: cbQuery.ID, Text: text})
+		return
+	}
+	c.ShouldSend(tg.CallbackConfig{CallbackQueryID: cbQuery.ID, Text: text})
+	c.ShouldSend(tg.NewEditMessageText(c.ID, cbQuery.Message.MessageID, emoji.Parse(":no_bell: ")+text))
+}
+
+// SendReminder sends the event of the reminder again, with the fresh post
+// content.
+func (c *Chat) SendReminder(reminder *store.Reminder) {
+	p := message.NewPrinter(c.State.Language)
+	event := reminder.Event
+
+	if reminder.MessageID != 0 {
+		// Remove the "Cancel" button
+		c.ShouldSend(tg.NewEditMessageReplyMarkup(c.ID, reminder.MessageID,
+			tg.InlineKeyboardMarkup{InlineKeyboard: [][]tg.InlineKeyboardButton{}}))
+	}
+
+	event.Post, event.Comment = nil, nil
+	if err := event.LoadPost(c.frfAPI()); err != nil {
+		if frf.IsUnauthorized(err) {
+			// User is already notified about the revoked token
+			return
+		}
+		c.ShouldSend(c.newHTMLMessage(p.Sprintf(
+			":alarm_clock: Reminder: the post is not available anymore (%v)",
+			err,
+		)))
+		return
+	}
+
+	msg := c.newHTMLMessage(p.Sprintf(":alarm_clock: Reminder:"))
+	if event.CommentID != uuid.Nil {
+		c.sendEventMessage(c.withCommentBody(msg, event), event)
+	} else {
+		c.sendEventMessage(c.withPostBody(msg, event), event)
+	}
+}
@@ -0,0 +1,54 @@
+package chat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FreeFeed/freefeed-tg-client/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseReminderDelay(t *testing.T) {
+	tests := []struct {
+		text    string
+		delay   time.Duration
+		isError bool
+	}{
+		{"30m", 30 * time.Minute, false},
+		{"2h", 2 * time.Hour, false},
+		{"1d 12h", 36 * time.Hour, false},
+		{"1D2H30M", 26*time.Hour + 30*time.Minute, false},
+		{"", 0, true},
+		{"0m", 0, true},
+		{"tomorrow", 0, true},
+		{"30m2h", 0, true},
+		{"400d", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			delay, err := parseReminderDelay(tt.text)
+			if tt.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.delay, delay)
+		})
+	}
+}
+
+func TestReminderTimeOfLostMessage(t *testing.T) {
+	app := &fakeApp{}
+	c := &Chat{ID: 123, App: app, State: &store.State{
+		ID:               123,
+		Expectation:      store.ExpectReminderTime,
+		ReactToMessageID: 321,
+	}}
+
+	c.handleReminderTime("2h")
+	assert.Empty(t, c.State.Expectation)
+	assert.Zero(t, c.State.ReactToMessageID)
+	assert.Len(t, app.saved, 1)
+	assert.Len(t, app.sent, 1)
+}
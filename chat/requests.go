@@ -0,0 +1,247 @@
+package chat
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	"github.com/FreeFeed/freefeed-tg-client/frf"
+	"github.com/enescakir/emoji"
+	tg "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"golang.org/x/text/message"
+)
+
+// Maximum number of requests shown in the list
+const maxRequestsListLength = 20
+
+// The callback data is "req:a:user[:group]" (accept), "req:r:user[:group]"
+// (reject), "req:a*hash" (accept all) or "req:r*hash" (reject all). The hash
+// of the listed requests limits the bulk actions to the requests user saw.
+// When the names don't fit in the callback data, the request is referenced by
+// its hash: "req:a#hash" or "req:r#hash".
+const requestPrefix = "req:"
+
+// subscriptionRequest is the one request to the current user (if group is
+// empty) or to the group.
+type subscriptionRequest struct {
+	user  string
+	group string
+}
+
+func flattenRequests(reqs *frf.SubscriptionRequests) []subscriptionRequest {
+	var result []subscriptionRequest
+	for _, u := range reqs.Users {
+		result = append(result, subscriptionRequest{user: u.Name})
+	}
+	for _, g := range reqs.Groups {
+		for _, u := range g.Users {
+			result = append(result, subscriptionRequest{user: u.Name, group: g.Group.Name})
+		}
+	}
+	return result
+}
+
+// answerRequest accepts or rejects the subscription request.
+func (c *Chat) answerRequest(req subscriptionRequest, accept bool) error {
+	switch {
+	case req.group == "" && accept:
+		return c.frfAPI().AcceptSubscriptionRequest(req.user)
+	case req.group == "":
+		return c.frfAPI().RejectSubscriptionRequest(req.user)
+	case accept:
+		return c.frfAPI().AcceptGroupSubscriptionRequest(req.user, req.group)
+	default:
+		return c.frfAPI().RejectGroupSubscriptionRequest(req.user, req.group)
+	}
+}
+
+func (c *Chat) handleRequestsCommand() {
+	text, markup := c.renderRequestsList()
+	msg := c.newRawHTMLMessage(text)
+	msg.ReplyMarkup = markup
+	c.ShouldSend(msg)
+}
+
+// renderRequestsList renders the list of pending requests with the buttons to
+// accept or reject them.
+func (c *Chat) renderRequestsList() (string, *tg.InlineKeyboardMarkup) {
+	p := message.NewPrinter(c.State.Language)
+
+	reqs, err := c.frfAPI().GetSubscriptionRequests()
+	if err != nil {
+		return c.App.Linkify(p.Sprintf("Cannot load the subscription requests: %v", err)), nil
+	}
+	list := flattenRequests(reqs)
+	if len(list) == 0 {
+		return c.App.Linkify(p.Sprintf("You have no pending subscription requests.")), nil
+	}
+
+	var (
+		lines []string
+		rows  [][]tg.InlineKeyboardButton
+	)
+	shown := list[:min(len(list), maxRequestsListLength)]
+	for i, req := range shown {
+		number := i + 1
+		user := "@" + req.user
+		line := p.Sprintf("%s wants to subscribe to you", user)
+		if req.group != "" {
+			group := "@" + req.group
+			line = p.Sprintf("%s wants to join %s", user, group)
+		}
+		lines = append(lines, fmt.Sprintf("%d. %s", number, c.App.Linkify(line)))
+		rows = append(rows, []tg.InlineKeyboardButton{
+			tg.NewInlineKeyboardButtonData(
+				emoji.Parse(p.Sprintf(":white_check_mark: Accept #%d", number)),
+				requestData(req, true),
+			),
+			tg.NewInlineKeyboardButtonData(
+				emoji.Parse(p.Sprintf(":x: Reject #%d", number)),
+				requestData(req, false),
+			),
+		})
+	}
+	if len(shown) > 1 {
+		acceptText := p.Sprintf(":white_check_mark: Accept all")
+		rejectText := p.Sprintf(":x: Reject all")
+		if len(shown) < len(list) {
+			count := len(shown)
+			acceptText = p.Sprintf(":white_check_mark: Accept these %d", count)
+			rejectText = p.Sprintf(":x: Reject these %d", count)
+		}
+		hash := requestsHash(shown...)
+		rows = append(rows, []tg.InlineKeyboardButton{
+			tg.NewInlineKeyboardButtonData(emoji.Parse(acceptText), requestPrefix+"a*"+hash),
+			tg.NewInlineKeyboardButtonData(emoji.Parse(rejectText), requestPrefix+"r*"+hash),
+		})
+	}
+
+	total := len(list)
+	text := c.App.Linkify(p.Sprintf("Pending subscription requests (%d):", total)) + "\n\n" + strings.Join(lines, "\n")
+	return text, &tg.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+// requestsHash returns the short hash of the requests list to use in the
+// callback data.
+func requestsHash(list ...subscriptionRequest) string {
+	h := fnv.New32a()
+	for _, req := range list {
+		h.Write([]byte(req.user + ":" + req.group + "\n"))
+	}
+	return strconv.FormatUint(uint64(h.Sum32()), 36)
+}
+
+func requestData(req subscriptionRequest, accept bool) string {
+	data := requestPrefix + "r"
+	if accept {
+		data = requestPrefix + "a"
+	}
+	named := data + ":" + req.user
+	if req.group != "" {
+		named += ":" + req.group
+	}
+	if len(named) > maxCallbackDataLength {
+		return data + "#" + requestsHash(req)
+	}
+	return named
+}
+
+// requestAction is the parsed request callback data. The request is defined
+// either by the req, or by the hash of the request (or of the whole list, if
+// all is true).
+type requestAction struct {
+	req    subscriptionRequest
+	hash   string
+	accept bool
+	all    bool
+}
+
+// parseRequestData parses the request callback data.
+func parseRequestData(data string) (action requestAction, ok bool) {
+	data, ok = strings.CutPrefix(data, requestPrefix)
+	if !ok || len(data) < 2 || (data[0] != 'a' && data[0] != 'r') {
+		return action, false
+	}
+	action.accept = data[0] == 'a'
+	switch data[1] {
+	case '*', '#':
+		action.all = data[1] == '*'
+		action.hash = data[2:]
+		return action, action.hash != ""
+	case ':':
+		action.req.user, action.req.group, _ = strings.Cut(data[2:], ":")
+		if !userNameRe.MatchString(action.req.user) ||
+			(action.req.group != "" && !userNameRe.MatchString(action.req.group)) {
+			return action, false
+		}
+		return action, true
+	}
+	return action, false
+}
+
+// resolveRequests returns the requests referenced by the hash of the action.
+// It returns nil if the requests are changed since the list was rendered.
+func (c *Chat) resolveRequests(action requestAction) ([]subscriptionRequest, error) {
+	reqs, err := c.frfAPI().GetSubscriptionRequests()
+	if err != nil {
+		return nil, err
+	}
+	list := flattenRequests(reqs)
+
+	if action.all {
+		shown := list[:min(len(list), maxRequestsListLength)]
+		if requestsHash(shown...) != action.hash {
+			return nil, nil
+		}
+		return shown, nil
+	}
+	for _, req := range list {
+		if requestsHash(req) == action.hash {
+			return []subscriptionRequest{req}, nil
+		}
+	}
+	return nil, nil
+}
+
+// handleRequestCallback accepts or rejects one or all requests and updates the
+// list.
+func (c *Chat) handleRequestCallback(cbQuery *tg.CallbackQuery, action requestAction) {
+	p := message.NewPrinter(c.State.Language)
+
+	list := []subscriptionRequest{action.req}
+	if action.hash != "" {
+		var err error
+		list, err = c.resolveRequests(action)
+		if err != nil {
+			c.ShouldSend(tg.CallbackConfig{
+				CallbackQueryID: cbQuery.ID,
+				Text:            emoji.Parse(p.Sprintf(":warning: FreeFeed error: %v", err)),
+			})
+			return
+		}
+	}
+
+	var failed int
+	for _, r := range list {
+		if err := c.answerRequest(r, action.accept); err != nil {
+			c.errorLog().Printf("Cannot answer request of %s: %v", r.user, err)
+			failed++
+		}
+	}
+
+	text := p.Sprintf("Accepted!")
+	if !action.accept {
+		text = p.Sprintf("Rejected!")
+	}
+	if len(list) == 0 {
+		text = p.Sprintf("The requests have changed, please check the updated list")
+	} else if failed > 0 {
+		total := len(list)
+		text = p.Sprintf("Cannot process %d of %d requests", failed, total)
+	}
+	c.ShouldSend(tg.CallbackConfig{CallbackQueryID: cbQuery.ID, Text: text})
+
+	newText, markup := c.renderRequestsList()
+	c.editListMessage(cbQuery.Message.MessageID, newText, markup)
+}
@@ -0,0 +1,51 @@
+package chat
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestData(t *testing.T) {
+	tests := []struct {
+		req    subscriptionRequest
+		accept bool
+	}{
+		{subscriptionRequest{user: "alice"}, true},
+		{subscriptionRequest{user: "alice"}, false},
+		{subscriptionRequest{user: "bob-smith", group: "cats"}, true},
+	}
+	for _, tt := range tests {
+		action, ok := parseRequestData(requestData(tt.req, tt.accept))
+		assert.True(t, ok)
+		assert.False(t, action.all)
+		assert.Equal(t, tt.req, action.req)
+		assert.Equal(t, tt.accept, action.accept)
+	}
+
+	action, ok := parseRequestData("req:r*abc")
+	assert.True(t, ok)
+	assert.True(t, action.all)
+	assert.False(t, action.accept)
+	assert.Equal(t, "abc", action.hash)
+
+	for _, data := range []string{"req:", "req:x:alice", "req:a:", "req:a:Alice!", "req:aalice", "req:r*", "req:a#"} {
+		_, ok := parseRequestData(data)
+		assert.False(t, ok, data)
+	}
+}
+
+func TestLongRequestData(t *testing.T) {
+	req := subscriptionRequest{user: strings.Repeat("u", 25), group: strings.Repeat("g", 35)}
+
+	data := requestData(req, true)
+	assert.LessOrEqual(t, len(data), maxCallbackDataLength)
+
+	action, ok := parseRequestData(data)
+	assert.True(t, ok)
+	assert.True(t, action.accept)
+	assert.False(t, action.all)
+	assert.Equal(t, requestsHash(req), action.hash)
+	assert.NotEqual(t, requestsHash(req), requestsHash(req, req))
+}
@@ -0,0 +1,311 @@
+package chat
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/FreeFeed/freefeed-tg-client/frf"
+	"github.com/FreeFeed/freefeed-tg-client/store"
+	"github.com/enescakir/emoji"
+	tg "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/gofrs/uuid"
+	"golang.org/x/text/message"
+)
+
+// How many saved posts to show on one page
+const savedPageSize = 5
+
+// How many server-side saved posts to load per request and in total, when the
+// sync is enabled
+const (
+	remoteSavedPageSize = 100
+	maxRemoteSavedPosts = 500
+)
+
+const (
+	savedPagePrefix = "saved:"
+	unsavePrefix    = "unsave:"
+)
+
+// remoteSaves is the loaded list of the server-side saved posts. The truncated
+// is true when the server has more saved posts than loaded.
+type remoteSaves struct {
+	posts     []*frf.Post
+	truncated bool
+}
+
+// setPostSaved saves or unsaves the post of the event. If the sync is
+// enabled, it does the same on the FreeFeed server.
+func (c *Chat) setPostSaved(event *frf.Event, saved bool) error {
+	if c.App.SyncSavesEnabled() {
+		var err error
+		if saved {
+			err = c.frfAPI().SavePost(event.PostID)
+		} else {
+			err = c.frfAPI().UnsavePost(event.PostID)
+		}
+		if err != nil && (saved || !frf.IsNotAccessible(err)) {
+			return err
+		}
+		c.updateRemoteSaves(event, saved)
+	}
+
+	if !saved {
+		err := c.App.UnsavePost(c.ID, event.PostID)
+		if errors.Is(err, store.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	return c.App.SavePost(c.ID, store.SavedPost{
+		PostID:  event.PostID,
+		Digest:  event.Post.Digest(),
+		SavedAt: time.Now(),
+	})
+}
+
+// isPostSaved checks if the post is in the list shown by the "/saved" command.
+func (c *Chat) isPostSaved(postID uuid.UUID) (bool, error) {
+	if !c.App.SyncSavesEnabled() {
+		return c.App.IsPostSaved(c.ID, postID)
+	}
+	posts, _, err := c.listSavedPosts()
+	if err != nil {
+		return false, err
+	}
+	for _, post := range posts {
+		if post.PostID == postID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// handleSavedCommand handles the "/saved" command.
+func (c *Chat) handleSavedCommand() {
+	// The command always shows the actual server-side list, the pages and
+	// buttons use the cached one
+	c.App.RemoteSavesCache().Remove(c.ID)
+	text, markup := c.renderSavedPage(0)
+	msg := c.newRawHTMLMessage(text)
+	msg.ReplyMarkup = markup
+	c.ShouldSend(msg)
+}
+
+// handleSavedPageCallback shows the other page of the saved posts in the same
+// message.
+func (c *Chat) handleSavedPageCallback(cbQuery *tg.CallbackQuery, offset int) {
+	c.ShouldSend(tg.CallbackConfig{CallbackQueryID: cbQuery.ID})
+	c.editSavedPage(cbQuery.Message.MessageID, offset)
+}
+
+// handleUnsaveCallback removes the post from the saved posts and updates the
+// list.
+func (c *Chat) handleUnsaveCallback(cbQuery *tg.CallbackQuery, postID uuid.UUID, offset int) {
+	p := message.NewPrinter(c.State.Language)
+
+	text := p.Sprintf("Post is removed from saved")
+	if err := c.setPostSaved(&frf.Event{PostID: postID}, false); err != nil {
+		c.errorLog().Print(err)
+		text = p.Sprintf("Cannot remove post from saved: %v", err)
+	}
+	c.ShouldSend(tg.CallbackConfig{CallbackQueryID: cbQuery.ID, Text: text})
+	c.editSavedPage(cbQuery.Message.MessageID, offset)
+}
+
+func (c *Chat) editSavedPage(messageID int, offset int) {
+	text, markup := c.renderSavedPage(offset)
+	c.editListMessage(messageID, text, markup)
+}
+
+// listSavedPosts returns the saved posts of the chat. If the sync is enabled,
+// the server-side saved posts (including the ones saved on the site) are
+// merged in. The truncated is true when the server has more saved posts than
+// loaded.
+func (c *Chat) listSavedPosts() (posts []store.SavedPost, truncated bool, err error) {
+	posts, err = c.App.ListSavedPosts(c.ID)
+	if err != nil || !c.App.SyncSavesEnabled() {
+		return posts, false, err
+	}
+
+	remote, err := c.remoteSavedPosts()
+	if err != nil {
+		return nil, false, err
+	}
+	return mergeSavedPosts(posts, remote.posts), remote.truncated, nil
+}
+
+// remoteSavedPosts returns the server-side saved posts from the cache or loads
+// them from the server.
+func (c *Chat) remoteSavedPosts() (*remoteSaves, error) {
+	cache := c.App.RemoteSavesCache()
+	if cached, err := cache.Get(c.ID); err == nil {
+		return cached.(*remoteSaves), nil
+	}
+
+	remote := new(remoteSaves)
+	for {
+		timeline, err := c.frfAPI().GetSavedPosts(len(remote.posts), remoteSavedPageSize)
+		if err != nil {
+			return nil, err
+		}
+		remote.posts = append(remote.posts, timeline.Posts...)
+		if timeline.IsLastPage || len(timeline.Posts) == 0 {
+			break
+		}
+		if len(remote.posts) >= maxRemoteSavedPosts {
+			remote.truncated = true
+			break
+		}
+	}
+	c.ShouldOK(cache.Set(c.ID, remote))
+	return remote, nil
+}
+
+// updateRemoteSaves applies the change made by the bot to the cached
+// server-side saved posts, so they don't need to be reloaded.
+func (c *Chat) updateRemoteSaves(event *frf.Event, saved bool) {
+	cache := c.App.RemoteSavesCache()
+	cached, err := cache.Get(c.ID)
+	if err != nil {
+		return
+	}
+
+	// The cached value may be in use by the other handlers, so make a copy
+	old := cached.(*remoteSaves)
+	remote := &remoteSaves{truncated: old.truncated}
+	if saved {
+		remote.posts = append(remote.posts, event.Post)
+	}
+	for _, post := range old.posts {
+		if post.ID != event.PostID {
+			remote.posts = append(remote.posts, post)
+		}
+	}
+	c.ShouldOK(cache.Set(c.ID, remote))
+}
+
+// mergeSavedPosts returns the server-side saved posts followed by the local
+// ones absent on the server (e.g. saved before the sync was enabled).
+func mergeSavedPosts(local []store.SavedPost, remote []*frf.Post) []store.SavedPost {
+	var result []store.SavedPost
+	seen := make(map[uuid.UUID]bool)
+	for _, post := range remote {
+		if seen[post.ID] {
+			continue
+		}
+		seen[post.ID] = true
+		result = append(result, store.SavedPost{PostID: post.ID, Digest: post.Digest()})
+	}
+	for _, post := range local {
+		if !seen[post.PostID] {
+			result = append(result, post)
+		}
+	}
+	return result
+}
+
+// renderSavedPage renders the page of the saved posts with the buttons to
+// open and remove them.
+func (c *Chat) renderSavedPage(offset int) (string, *tg.InlineKeyboardMarkup) {
+	p := message.NewPrinter(c.State.Language)
+
+	posts, truncated, err := c.listSavedPosts()
+	if err != nil {
+		c.errorLog().Print(err)
+		return c.App.Linkify(p.Sprintf("Cannot load the saved posts: %v", err)), nil
+	}
+	if len(posts) == 0 {
+		return c.App.Linkify(p.Sprintf("You have no saved posts. Use the \"Save\" button to save one.")), nil
+	}
+
+	// The last page may become empty after the removal
+	for offset >= len(posts) {
+		offset -= savedPageSize
+	}
+	offset = max(offset, 0)
+	page := posts[offset:min(offset+savedPageSize, len(posts))]
+
+	var (
+		lines []string
+		rows  [][]tg.InlineKeyboardButton
+	)
+	for i, post := range page {
+		number := offset + i + 1
+		postURL := fmt.Sprintf("https://%s/posts/%s", c.frfAPI().HostName, post.PostID)
+		lines = append(lines, fmt.Sprintf(`%d. <a href="%s">%s</a>`,
+			number, postURL, c.App.Linkify(c.App.ContentOf(post.Digest))))
+		rows = append(rows, []tg.InlineKeyboardButton{
+			tg.NewInlineKeyboardButtonURL(
+				emoji.Parse(p.Sprintf(":globe_with_meridians: Open #%d", number)),
+				postURL,
+			),
+			tg.NewInlineKeyboardButtonData(
+				emoji.Parse(p.Sprintf(":x: Remove #%d", number)),
+				unsaveData(post.PostID, offset),
+			),
+		})
+	}
+
+	var navRow []tg.InlineKeyboardButton
+	if offset > 0 {
+		navRow = append(navRow, tg.NewInlineKeyboardButtonData(
+			emoji.Parse(p.Sprintf(":arrow_up: Previous page")),
+			savedPagePrefix+strconv.Itoa(max(offset-savedPageSize, 0)),
+		))
+	}
+	if offset+savedPageSize < len(posts) {
+		navRow = append(navRow, tg.NewInlineKeyboardButtonData(
+			emoji.Parse(p.Sprintf(":arrow_down: Next page")),
+			savedPagePrefix+strconv.Itoa(offset+savedPageSize),
+		))
+	}
+	if len(navRow) > 0 {
+		rows = append(rows, navRow)
+	}
+
+	total := len(posts)
+	text := c.App.Linkify(p.Sprintf("Your saved posts (%d):", total)) + "\n\n" + strings.Join(lines, "\n")
+	if truncated {
+		savesURL := fmt.Sprintf("https://%s/filter/saves", c.frfAPI().HostName)
+		text += "\n\n" + c.App.Linkify(p.Sprintf("Only the latest %d saved posts are shown, see all of them on the site:", maxRemoteSavedPosts)) +
+			" " + savesURL
+	}
+	return text, &tg.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+func unsaveData(postID uuid.UUID, offset int) string {
+	return unsavePrefix + postID.String() + ":" + strconv.Itoa(offset)
+}
+
+func parseSavedPageData(data string) (int, bool) {
+	data, ok := strings.CutPrefix(data, savedPagePrefix)
+	if !ok {
+		return 0, false
+	}
+	offset, err := strconv.Atoi(data)
+	return offset, err == nil && offset >= 0
+}
+
+func parseUnsaveData(data string) (uuid.UUID, int, bool) {
+	data, ok := strings.CutPrefix(data, unsavePrefix)
+	if !ok {
+		return uuid.Nil, 0, false
+	}
+	idStr, offsetStr, ok := strings.Cut(data, ":")
+	if !ok {
+		return uuid.Nil, 0, false
+	}
+	id, err := uuid.FromString(idStr)
+	if err != nil {
+		return uuid.Nil, 0, false
+	}
+	offset, err := strconv.Atoi(offsetStr)
+	if err != nil || offset < 0 {
+		return uuid.Nil, 0, false
+	}
+	return id, offset, true
+}
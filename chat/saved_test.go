@@ -0,0 +1,71 @@
+package chat
+
+import (
+	"testing"
+
+	"github.com/FreeFeed/freefeed-tg-client/frf"
+	"github.com/FreeFeed/freefeed-tg-client/store"
+	"github.com/bluele/gcache"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnsaveData(t *testing.T) {
+	postID := uuid.Must(uuid.NewV4())
+
+	id, offset, ok := parseUnsaveData(unsaveData(postID, 10))
+	assert.True(t, ok)
+	assert.Equal(t, postID, id)
+	assert.Equal(t, 10, offset)
+
+	_, _, ok = parseUnsaveData("unsave:" + postID.String())
+	assert.False(t, ok)
+	_, _, ok = parseUnsaveData("unsave:xxx:0")
+	assert.False(t, ok)
+	_, _, ok = parseUnsaveData("unsave:" + postID.String() + ":-5")
+	assert.False(t, ok)
+}
+
+func TestMergeSavedPosts(t *testing.T) {
+	onBoth := uuid.Must(uuid.NewV4())
+	onSite := uuid.Must(uuid.NewV4())
+	localOnly := uuid.Must(uuid.NewV4())
+
+	local := []store.SavedPost{{PostID: localOnly}, {PostID: onBoth}}
+	remote := []*frf.Post{{ID: onSite, Body: "from site"}, {ID: onBoth}}
+
+	var ids []uuid.UUID
+	for _, post := range mergeSavedPosts(local, remote) {
+		ids = append(ids, post.PostID)
+	}
+	assert.Equal(t, []uuid.UUID{onSite, onBoth, localOnly}, ids)
+	assert.Equal(t, "from site", mergeSavedPosts(nil, remote)[0].Digest)
+}
+
+func TestRemoteSavesCache(t *testing.T) {
+	onSite := &frf.Post{ID: uuid.Must(uuid.NewV4())}
+	localOnly := store.SavedPost{PostID: uuid.Must(uuid.NewV4())}
+	saves := gcache.New(10).Build()
+	app := &fakeApp{saves: saves, localSaves: []store.SavedPost{localOnly}}
+	c := &Chat{ID: 123, App: app, State: &store.State{ID: 123}}
+
+	// The cached list is used, so no requests are sent to the server
+	assert.NoError(t, saves.Set(c.ID, &remoteSaves{posts: []*frf.Post{onSite}}))
+	for _, postID := range []uuid.UUID{onSite.ID, localOnly.PostID} {
+		saved, err := c.isPostSaved(postID)
+		assert.NoError(t, err)
+		assert.True(t, saved)
+	}
+
+	newPost := &frf.Post{ID: uuid.Must(uuid.NewV4())}
+	c.updateRemoteSaves(&frf.Event{PostID: newPost.ID, Post: newPost}, true)
+	c.updateRemoteSaves(&frf.Event{PostID: onSite.ID}, false)
+
+	posts, _, err := c.listSavedPosts()
+	assert.NoError(t, err)
+	var ids []uuid.UUID
+	for _, post := range posts {
+		ids = append(ids, post.PostID)
+	}
+	assert.Equal(t, []uuid.UUID{newPost.ID, localOnly.PostID}, ids)
+}
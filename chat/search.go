@@ -0,0 +1,65 @@
+package chat
+
+import (
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/message"
+)
+
+// handleSearchCommand handles the "/search query" command. The query is kept
+// in the chat state for the pagination.
+func (c *Chat) handleSearchCommand(args string) {
+	p := message.NewPrinter(c.State.Language)
+
+	query := strings.TrimSpace(args)
+	if query == "" {
+		c.ShouldSend(c.newHTMLMessage(p.Sprintf("Usage: /search query")))
+		return
+	}
+
+	c.State.SearchQuery = query
+	c.State.SearchSeq++
+	c.ShouldOK(c.saveState())
+
+	c.showSearchResults(0)
+}
+
+func (c *Chat) showSearchResults(offset int) {
+	p := message.NewPrinter(c.State.Language)
+
+	if c.State.SearchQuery == "" {
+		c.ShouldSend(c.newHTMLMessage(p.Sprintf("This search is outdated, please repeat it.")))
+		return
+	}
+
+	results, err := c.frfAPI().Search(c.State.SearchQuery, offset, timelinePageSize)
+	if err != nil {
+		c.ShouldSend(c.newHTMLMessage(p.Sprintf(":alien: Cannot search: %v", err)))
+		return
+	}
+
+	c.showPosts(results, searchPageData(c.State.SearchSeq, offset+len(results.Posts)))
+}
+
+// The callback data is "search:seq:offset", where the seq is the
+// State.SearchSeq of the search.
+const searchPagePrefix = "search:"
+
+func searchPageData(seq, offset int) string {
+	return searchPagePrefix + strconv.Itoa(seq) + ":" + strconv.Itoa(offset)
+}
+
+func parseSearchPageData(data string) (seq, offset int, ok bool) {
+	data, ok = strings.CutPrefix(data, searchPagePrefix)
+	if !ok {
+		return 0, 0, false
+	}
+	seqStr, offsetStr, ok := strings.Cut(data, ":")
+	if !ok {
+		return 0, 0, false
+	}
+	seq, err1 := strconv.Atoi(seqStr)
+	offset, err2 := strconv.Atoi(offsetStr)
+	return seq, offset, err1 == nil && err2 == nil
+}
@@ -0,0 +1,19 @@
+package chat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchPageData(t *testing.T) {
+	seq, offset, ok := parseSearchPageData(searchPageData(3, 20))
+	assert.True(t, ok)
+	assert.Equal(t, 3, seq)
+	assert.Equal(t, 20, offset)
+
+	for _, data := range []string{"search:", "search:40", "search:x", "search:1:x", "watch:1:2"} {
+		_, _, ok := parseSearchPageData(data)
+		assert.False(t, ok, data)
+	}
+}
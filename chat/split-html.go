@@ -0,0 +1,223 @@
+package chat
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxMessageLength is the Telegram limit of the message text length
+const maxMessageLength = 4096
+
+const truncationMark = "…"
+
+// htmlToken is the unit of the Telegram HTML text that cannot be split: a tag,
+// an HTML entity or a single character.
+type htmlToken struct {
+	text    string
+	tag     string // tag name, empty for the text tokens
+	closing bool
+}
+
+var htmlTokenRe = regexp.MustCompile(`^(?:<(/?)([a-zA-Z-]+)[^>]*>|&[#a-zA-Z0-9]+;)`)
+
+func tokenizeHTML(html string) []htmlToken {
+	var tokens []htmlToken
+	for len(html) > 0 {
+		if m := htmlTokenRe.FindStringSubmatch(html); m != nil {
+			tokens = append(tokens, htmlToken{text: m[0], tag: strings.ToLower(m[2]), closing: m[1] != ""})
+			html = html[len(m[0]):]
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(html)
+		tokens = append(tokens, htmlToken{text: html[:size]})
+		html = html[size:]
+	}
+	return tokens
+}
+
+// textLength returns the text length in UTF-16 code units, as Telegram counts
+// it.
+func textLength(s string) int {
+	n := 0
+	for _, r := range s {
+		if r >= 0x10000 {
+			n += 2
+		} else {
+			n++
+		}
+	}
+	return n
+}
+
+// splitHTML splits the Telegram HTML text into the parts no longer than limit.
+// It prefers to split by paragraphs, then by lines, then by words. The
+// formatting tags (except the links, which are never split) are closed at the
+// end of the part and reopened at the start of the next one.
+func splitHTML(html string, limit int) []string {
+	if textLength(html) <= limit {
+		return []string{html}
+	}
+
+	type cutPoint struct {
+		idx    int
+		rank   int
+		length int
+		stack  []htmlToken
+	}
+
+	tokens := tokenizeHTML(html)
+	var (
+		parts []string
+		stack []htmlToken // opened tags
+	)
+
+	for start := 0; start < len(tokens); {
+		prefix := openingTags(stack)
+		length := textLength(prefix)
+		curStack := append([]htmlToken(nil), stack...)
+
+		var cuts []cutPoint
+		end := start
+		for ; end < len(tokens); end++ {
+			tok := tokens[end]
+			length += textLength(tok.text)
+			if length > limit {
+				break
+			}
+			curStack = applyTag(curStack, tok)
+			if hasTag(curStack, "a") {
+				// Never split the links
+				continue
+			}
+			if length+textLength(closingTags(curStack)) > limit {
+				continue
+			}
+			cuts = append(cuts, cutPoint{
+				idx:    end + 1,
+				rank:   cutRank(tokens, end),
+				length: length,
+				stack:  append([]htmlToken(nil), curStack...),
+			})
+		}
+
+		if end == len(tokens) {
+			// The rest fits into the one part
+			parts = append(parts, prefix+joinTokens(tokens[start:end]))
+			break
+		}
+
+		var cut cutPoint
+		for rank := 3; rank >= 0; rank-- {
+			for i := len(cuts) - 1; i >= 0; i-- {
+				if cuts[i].rank >= rank && (rank == 0 || cuts[i].length >= limit/2) {
+					cut = cuts[i]
+					break
+				}
+			}
+			if cut.idx != 0 {
+				break
+			}
+		}
+		if cut.idx == 0 {
+			// No safe place to cut (a very long link?), cut it anyway
+			cut.idx = max(end, start+1)
+			cut.stack = stack
+			for _, tok := range tokens[start:cut.idx] {
+				cut.stack = applyTag(cut.stack, tok)
+			}
+		}
+
+		part := strings.TrimRight(prefix+joinTokens(tokens[start:cut.idx]), " \n")
+		parts = append(parts, part+closingTags(cut.stack))
+
+		stack = cut.stack
+		start = cut.idx
+		// Skip the leading whitespaces of the next part
+		for start < len(tokens) && (tokens[start].text == " " || tokens[start].text == "\n") {
+			start++
+		}
+	}
+
+	return parts
+}
+
+// cutRank returns the preference of cutting after the idx token: 3 for the
+// paragraph boundary, 2 for the line boundary, 1 for the word boundary and 0
+// for the others.
+func cutRank(tokens []htmlToken, idx int) int {
+	newLines, spaces := 0, 0
+	count := func(tok htmlToken) bool {
+		switch tok.text {
+		case "\n":
+			newLines++
+		case " ":
+			spaces++
+		default:
+			return false
+		}
+		return true
+	}
+	for i := idx; i >= 0 && count(tokens[i]); i-- {
+	}
+	for i := idx + 1; i < len(tokens) && count(tokens[i]); i++ {
+	}
+
+	switch {
+	case newLines >= 2:
+		return 3
+	case newLines == 1:
+		return 2
+	case spaces > 0:
+		return 1
+	}
+	return 0
+}
+
+func applyTag(stack []htmlToken, tok htmlToken) []htmlToken {
+	if tok.tag == "" {
+		return stack
+	}
+	if !tok.closing {
+		return append(stack, tok)
+	}
+	for i := len(stack) - 1; i >= 0; i-- {
+		if stack[i].tag == tok.tag {
+			return append(stack[:i:i], stack[i+1:]...)
+		}
+	}
+	return stack
+}
+
+func hasTag(stack []htmlToken, tag string) bool {
+	for _, t := range stack {
+		if t.tag == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func openingTags(stack []htmlToken) string {
+	var b strings.Builder
+	for _, t := range stack {
+		b.WriteString(t.text)
+	}
+	return b.String()
+}
+
+func closingTags(stack []htmlToken) string {
+	var b strings.Builder
+	for i := len(stack) - 1; i >= 0; i-- {
+		b.WriteString("</" + stack[i].tag + ">")
+	}
+	return b.String()
+}
+
+func joinTokens(tokens []htmlToken) string {
+	var b strings.Builder
+	for _, t := range tokens {
+		b.WriteString(t.text)
+	}
+	return b.String()
+}
@@ -0,0 +1,87 @@
+package chat
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitHTML(t *testing.T) {
+	link := `<a href="https://freefeed.net/username">@username</a>`
+
+	tests := []struct {
+		name  string
+		html  string
+		limit int
+		parts []string
+	}{
+		{
+			name:  "short text",
+			html:  "hello, world",
+			limit: 20,
+			parts: []string{"hello, world"},
+		},
+		{
+			name:  "paragraphs",
+			html:  "first paragraph\n\nsecond paragraph",
+			limit: 20,
+			parts: []string{"first paragraph", "second paragraph"},
+		},
+		{
+			name:  "lines are preferred to words",
+			html:  "first line\nsecond line",
+			limit: 15,
+			parts: []string{"first line", "second line"},
+		},
+		{
+			name:  "words",
+			html:  "one two three four five",
+			limit: 10,
+			parts: []string{"one two", "three four", "five"},
+		},
+		{
+			name:  "entities are not split",
+			html:  "aaaa&amp;bbbb",
+			limit: 6,
+			parts: []string{"aaaa", "&amp;b", "bbb"},
+		},
+		{
+			name:  "links are not split",
+			html:  "hello " + link,
+			limit: 55,
+			parts: []string{"hello", link},
+		},
+		{
+			name:  "word boundary after the link",
+			html:  "hello " + link + " bye",
+			limit: 60,
+			parts: []string{"hello " + link, "bye"},
+		},
+		{
+			name:  "formatting is reopened",
+			html:  "<tg-spoiler>one two three</tg-spoiler>",
+			limit: 35,
+			parts: []string{"<tg-spoiler>one two</tg-spoiler>", "<tg-spoiler>three</tg-spoiler>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.parts, splitHTML(tt.html, tt.limit))
+		})
+	}
+}
+
+func TestSplitHTMLLimit(t *testing.T) {
+	html := strings.Repeat("Lorem ipsum dolor sit amet, <b>consectetur</b> adipiscing elit.\n", 300)
+	for _, part := range splitHTML(html, maxMessageLength) {
+		assert.LessOrEqual(t, textLength(part), maxMessageLength)
+	}
+}
+
+func TestTextLength(t *testing.T) {
+	assert.Equal(t, 5, textLength("hello"))
+	assert.Equal(t, 6, textLength("привет"))
+	assert.Equal(t, 2, textLength("😀"))
+}
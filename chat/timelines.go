@@ -0,0 +1,117 @@
+package chat
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/FreeFeed/freefeed-tg-client/frf"
+	"github.com/FreeFeed/freefeed-tg-client/store"
+	"github.com/enescakir/emoji"
+	tg "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"golang.org/x/text/message"
+)
+
+// How many posts to show at once
+const timelinePageSize = 5
+
+// Synthetic event type for the posts shown from the timelines
+const timelinePostEvent = "__timeline:post"
+
+// handleFeedCommand handles the "/feed", "/feed @user" and "/feed group"
+// commands.
+func (c *Chat) handleFeedCommand(args string) {
+	p := message.NewPrinter(c.State.Language)
+
+	feedPath := "home"
+	if name := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(args), "@")); name != "" {
+		if !userNameRe.MatchString(name) {
+			c.ShouldSend(c.newHTMLMessage(p.Sprintf("Usage: /feed [@username or group]")))
+			return
+		}
+		feedPath = name
+	}
+
+	c.showTimeline(feedPath, 0)
+}
+
+// showTimeline sends the page of the timeline posts.
+func (c *Chat) showTimeline(feedPath string, offset int) {
+	p := message.NewPrinter(c.State.Language)
+
+	timeline, err := c.frfAPI().GetTimeline(feedPath, offset, timelinePageSize)
+	if err != nil {
+		c.ShouldSend(c.newHTMLMessage(p.Sprintf(":alien: Cannot load posts: %v", err)))
+		return
+	}
+
+	c.showPosts(timeline, nextPageData(feedPath, offset+len(timeline.Posts)))
+}
+
+// showPosts sends the posts, one post per message, and the "Next page" button
+// with the given callback data.
+func (c *Chat) showPosts(timeline *frf.Timeline, nextPageData string) {
+	p := message.NewPrinter(c.State.Language)
+
+	if len(timeline.Posts) == 0 {
+		c.ShouldSend(c.newHTMLMessage(p.Sprintf("There are no posts here.")))
+		return
+	}
+
+	for _, post := range timeline.Posts {
+		event := &frf.Event{
+			Type:        timelinePostEvent,
+			PostID:      post.ID,
+			Post:        post,
+			CreatedUser: post.Author,
+		}
+		msg := c.renderTimelinePost(event)
+		rest := c.fitMessage(msg, event)
+		c.ShouldSendAndSave(msg, store.SentMsgRec{Event: event, Truncated: len(rest) > 0, Rest: rest})
+	}
+
+	if !timeline.IsLastPage {
+		msg := c.newHTMLMessage(p.Sprintf("Want to see more posts?"))
+		msg.ReplyMarkup = tg.NewInlineKeyboardMarkup([]tg.InlineKeyboardButton{
+			tg.NewInlineKeyboardButtonData(
+				emoji.Parse(p.Sprintf(":arrow_down: Next page")),
+				nextPageData,
+			),
+		})
+		c.ShouldSend(msg)
+	}
+}
+
+func (c *Chat) renderTimelinePost(event *frf.Event) *tg.MessageConfig {
+	p := message.NewPrinter(c.State.Language)
+
+	headText := p.Sprintf(":page_facing_up: Post:")
+	if event.CreatedUser != nil {
+		headText = p.Sprintf(":page_facing_up: Post by %s:", event.CreatedUser)
+	}
+	msg := c.newHTMLMessage(headText)
+	msg.Text += bodySeparator + c.App.ContentOf(c.App.Linkify(event.Post.Body))
+	msg.ReplyMarkup = c.postButtons(event)
+	return msg
+}
+
+const nextPagePrefix = "feed:"
+
+func nextPageData(feedPath string, offset int) string {
+	return nextPagePrefix + feedPath + ":" + strconv.Itoa(offset)
+}
+
+func parseNextPageData(data string) (feedPath string, offset int, ok bool) {
+	data, ok = strings.CutPrefix(data, nextPagePrefix)
+	if !ok {
+		return "", 0, false
+	}
+	idx := strings.LastIndex(data, ":")
+	if idx < 0 {
+		return "", 0, false
+	}
+	offset, err := strconv.Atoi(data[idx+1:])
+	if err != nil {
+		return "", 0, false
+	}
+	return data[:idx], offset, true
+}
@@ -0,0 +1,164 @@
+package chat
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/FreeFeed/freefeed-tg-client/frf"
+	"github.com/FreeFeed/freefeed-tg-client/store"
+	"github.com/enescakir/emoji"
+	tg "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/golang-jwt/jwt/v4"
+	"golang.org/x/text/message"
+)
+
+// requiredTokenScopes are the access token scopes the bot cannot work without.
+var requiredTokenScopes = []string{
+	"read-my-info",
+	"read-realtime",
+	"manage-notifications",
+	"manage-posts",
+}
+
+func (c *Chat) createTokenURL() string {
+	return "https://" + c.App.FreeFeedAPI().HostName +
+		"/settings/app-tokens/create?title=FreeFeed%20Telegram%20bot&scopes=read-my-info%20read-realtime%20manage-notifications%20manage-posts%20manage-subscription-requests%20manage-groups"
+}
+
+// onTokenRevoked is called when FreeFeed rejects the chat access token. It
+// notifies user (only once) and waits for the new token.
+func (c *Chat) onTokenRevoked() {
+	if !c.State.IsAuthorized() || c.State.TokenRevoked {
+		return
+	}
+
+	c.debugLog().Println("Access token was rejected by FreeFeed")
+
+	p := message.NewPrinter(c.State.Language)
+
+	c.App.StopRealtime(c.ID)
+
+	c.State.ClearExpectations()
+	c.State.TokenRevoked = true
+	c.State.Expectation = store.ExpectAuthToken
+	c.ShouldOK(c.saveState())
+
+	c.ShouldSend(c.newHTMLMessage(p.Sprintf(
+		":warning: FreeFeed has rejected your access token, probably it was revoked or expired. " +
+			"The bot will not show you updates until you send it a new token.",
+	)))
+	c.printExpectationMessage()
+}
+
+// CheckToken checks the chat access token by requesting the current user
+// info. If the token is rejected, the user will be notified.
+func (c *Chat) CheckToken() error {
+	_, err := c.frfAPI().GetMe()
+	return err
+}
+
+// parseToken parses the JWT access token without verification and returns its
+// expiration time (zero if token never expires).
+func parseToken(token string) (time.Time, error) {
+	claims := new(jwt.RegisteredClaims)
+	if _, _, err := new(jwt.Parser).ParseUnverified(token, claims); err != nil {
+		return time.Time{}, err
+	}
+	if claims.ExpiresAt == nil {
+		return time.Time{}, nil
+	}
+	return claims.ExpiresAt.Time, nil
+}
+
+// loadTokenInfo loads the token metadata from FreeFeed. It returns nil if
+// server doesn't support the app tokens info API.
+func loadTokenInfo(api *frf.API) (*frf.AppToken, error) {
+	info, err := api.GetCurrentAppToken()
+	var apiErr *frf.Error
+	if errors.As(err, &apiErr) && apiErr.HTTPStatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	return info, err
+}
+
+// missingTokenScopes returns the required scopes that the token doesn't have.
+func missingTokenScopes(info *frf.AppToken) []string {
+	if info == nil {
+		// We don't know the token scopes
+		return nil
+	}
+	var missing []string
+	for _, scope := range requiredTokenScopes {
+		if !slices.Contains(info.Scopes, scope) {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
+
+// setTokenInfo fills the token metadata fields of the chat state.
+func (c *Chat) setTokenInfo(expiresAt time.Time, info *frf.AppToken) {
+	c.State.TokenExpiresAt = expiresAt
+	c.State.TokenScopes = nil
+	c.State.TokenExpiryWarned = false
+	if info != nil {
+		if !info.ExpiresAt.IsZero() {
+			c.State.TokenExpiresAt = info.ExpiresAt
+		}
+		c.State.TokenScopes = info.Scopes
+	}
+}
+
+// UpdateTokenInfo reloads the metadata of the current access token and saves
+// it to the chat state.
+func (c *Chat) UpdateTokenInfo() error {
+	expiresAt, err := parseToken(c.State.AccessToken)
+	if err != nil {
+		return err
+	}
+	info, err := loadTokenInfo(c.frfAPI())
+	if err != nil {
+		return err
+	}
+	c.setTokenInfo(expiresAt, info)
+	return c.saveState()
+}
+
+// tokenExpiryWarningPeriod is how long before the token expiration the user
+// will be warned.
+const tokenExpiryWarningPeriod = 7 * 24 * time.Hour
+
+// TokenExpiresSoon returns true if the access token expires within the
+// warning period.
+func (c *Chat) TokenExpiresSoon(now time.Time) bool {
+	expiresAt := c.State.TokenExpiresAt
+	return !expiresAt.IsZero() && expiresAt.Sub(now) < tokenExpiryWarningPeriod
+}
+
+// WarnTokenExpiry notifies user that the access token expires soon.
+func (c *Chat) WarnTokenExpiry() {
+	p := message.NewPrinter(c.State.Language)
+
+	expirationDate := c.State.TokenExpiresAt.Format(time.DateOnly)
+	msg := c.newHTMLMessage(p.Sprintf(
+		":hourglass: Your FreeFeed access token expires on %s. "+
+			"Please create a new token and send it to the bot, otherwise the bot will stop working.",
+		expirationDate,
+	))
+	msg.ReplyMarkup = tg.NewInlineKeyboardMarkup([]tg.InlineKeyboardButton{
+		tg.NewInlineKeyboardButtonURL(
+			emoji.Parse(p.Sprintf(":key: Create new token")),
+			c.createTokenURL(),
+		),
+		tg.NewInlineKeyboardButtonData(
+			emoji.Parse(p.Sprintf(":inbox_tray: Send new token")),
+			doRenewToken,
+		),
+	})
+	if _, err := c.ShouldSend(msg); err == nil {
+		c.State.TokenExpiryWarned = true
+		c.ShouldOK(c.saveState())
+	}
+}
@@ -0,0 +1,93 @@
+package chat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FreeFeed/freefeed-tg-client/frf"
+	"github.com/FreeFeed/freefeed-tg-client/store"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signedToken(t *testing.T, claims jwt.Claims) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("secret"))
+	require.NoError(t, err)
+	return token
+}
+
+func TestParseToken(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	tests := []struct {
+		name      string
+		token     string
+		expiresAt time.Time
+		isError   bool
+	}{
+		{"with exp", signedToken(t, jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(expiresAt)}), expiresAt, false},
+		{"without exp", signedToken(t, jwt.RegisteredClaims{Subject: "user"}), time.Time{}, false},
+		// The bot is not the token verifier, so the expired token is parsed
+		{"expired", signedToken(t, jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(expiresAt.Add(-2 * time.Hour))}), expiresAt.Add(-2 * time.Hour), false},
+		{"empty", "", time.Time{}, true},
+		{"not a JWT", "abcdef", time.Time{}, true},
+		{"bad segments", "a.b.c", time.Time{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseToken(tt.token)
+			if tt.isError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.expiresAt.Equal(result), "expected %v, got %v", tt.expiresAt, result)
+		})
+	}
+}
+
+func TestMissingTokenScopes(t *testing.T) {
+	tests := []struct {
+		name    string
+		info    *frf.AppToken
+		missing []string
+	}{
+		{"unknown scopes", nil, nil},
+		{"all scopes", &frf.AppToken{Scopes: append([]string{"manage-groups"}, requiredTokenScopes...)}, nil},
+		{"no scopes", &frf.AppToken{}, requiredTokenScopes},
+		{
+			"some scopes",
+			&frf.AppToken{Scopes: []string{"read-my-info", "manage-posts"}},
+			[]string{"read-realtime", "manage-notifications"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.missing, missingTokenScopes(tt.info))
+		})
+	}
+}
+
+func TestTokenExpiresSoon(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		expiresAt time.Time
+		soon      bool
+	}{
+		{"never expires", time.Time{}, false},
+		{"far future", now.Add(30 * 24 * time.Hour), false},
+		{"at the window start", now.Add(tokenExpiryWarningPeriod), false},
+		{"just inside the window", now.Add(tokenExpiryWarningPeriod - time.Second), true},
+		{"tomorrow", now.Add(24 * time.Hour), true},
+		{"already expired", now.Add(-time.Hour), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Chat{State: &store.State{TokenExpiresAt: tt.expiresAt}}
+			assert.Equal(t, tt.soon, c.TokenExpiresSoon(now))
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package chat
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/FreeFeed/freefeed-tg-client/frf"
+	"github.com/FreeFeed/freefeed-tg-client/store"
+	tg "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"golang.org/x/text/message"
+)
+
+// handleTopicsCommand turns the topics mode on and off. In the topics mode the
+// bot creates a separate forum topic for every post.
+func (c *Chat) handleTopicsCommand(msg *tg.Message) {
+	p := message.NewPrinter(c.State.Language)
+
+	if c.State.TopicsOwnerID != 0 && !c.isTopicsOwner(msg.From) && !c.isChatAdmin(msg.From) {
+		c.ShouldSend(c.newHTMLMessage(p.Sprintf(
+			"Only the user who enabled the topics mode or a chat administrator can change it.",
+		)))
+		return
+	}
+
+	switch msg.CommandArguments() {
+	case "on":
+		if !msg.Chat.IsSuperGroup() || msg.From == nil {
+			c.ShouldSend(c.newHTMLMessage(p.Sprintf(
+				"The topics mode is available only in supergroups with topics enabled.",
+			)))
+			return
+		}
+		c.State.TopicsMode = true
+		c.State.TopicsOwnerID = msg.From.ID
+		c.ShouldOK(c.saveState())
+		c.ShouldSend(c.newHTMLMessage(p.Sprintf(
+			"The topics mode is on. The bot will create a topic for every post. " +
+				"Your messages in the topic will be posted as comments to the post. " +
+				"Make sure the bot is an admin with the right to manage topics.",
+		)))
+	case "off":
+		c.State.TopicsMode = false
+		c.State.TopicsOwnerID = 0
+		c.ShouldOK(c.saveState())
+		c.ShouldSend(c.newHTMLMessage(p.Sprintf("The topics mode is off.")))
+	default:
+		status := p.Sprintf("The topics mode is off.")
+		if c.State.TopicsMode {
+			status = p.Sprintf("The topics mode is on.")
+		}
+		c.ShouldSend(c.newHTMLMessage(
+			status + "\n" + p.Sprintf("Use \"/topics on\" or \"/topics off\" to change it."),
+		))
+	}
+}
+
+// isTopicsOwner returns true if the user is the one who enabled the topics
+// mode.
+func (c *Chat) isTopicsOwner(user *tg.User) bool {
+	return user != nil && user.ID == c.State.TopicsOwnerID
+}
+
+// isChatAdmin returns true if the user is an administrator of the chat.
+func (c *Chat) isChatAdmin(user *tg.User) bool {
+	if user == nil {
+		return false
+	}
+	isAdmin, err := c.App.IsChatAdmin(c.ID, user.ID)
+	return c.ShouldOK(err) == nil && isAdmin
+}
+
+// sendToPostTopic sends the event message to the topic of the event post,
+// creating the topic if needed. It returns false if the topic cannot be
+// created or the message cannot be sent to it, so the message should be sent
+// in the usual way.
+func (c *Chat) sendToPostTopic(msg *tg.MessageConfig, rec store.SentMsgRec) bool {
+	event := rec.Event
+
+	for attempt := 0; attempt < 2; attempt++ {
+		topic, err := c.App.GetPostTopic(c.ID, event.PostID)
+		if errors.Is(err, store.ErrNotFound) {
+			topic, err = c.createPostTopic(event)
+		}
+		if err != nil {
+			c.errorLog().Printf("Cannot get topic for post %s: %v", event.PostID, err)
+			return false
+		}
+
+		sent, err := c.App.SendToTopic(msg, topic.ThreadID)
+		c.checkUnreachable(err)
+		if isTopicNotFound(err) {
+			// The topic was deleted by the group admin, create it again
+			c.ShouldOK(c.App.DeletePostTopic(c.ID, event.PostID))
+			continue
+		} else if c.ShouldOK(err) != nil {
+			return false
+		}
+
+		rec.MessageID = sent.MessageID
+		c.ShouldOK(c.App.PutMsgRec(c.ID, rec))
+
+		topic.UpdatedAt = time.Now()
+		c.ShouldOK(c.App.PutPostTopic(c.ID, topic))
+		return true
+	}
+	return false
+}
+
+func (c *Chat) createPostTopic(event *frf.Event) (store.PostTopic, error) {
+	p := message.NewPrinter(c.State.Language)
+
+	name := p.Sprintf("Post")
+	if event.Post != nil {
+		if digest := c.App.ContentOf(event.Post.Digest()); digest != "" {
+			name = digest
+		}
+	}
+
+	threadID, err := c.App.CreateForumTopic(c.ID, name)
+	if err != nil {
+		return store.PostTopic{}, err
+	}
+
+	topic := store.PostTopic{PostID: event.PostID, ThreadID: threadID, UpdatedAt: time.Now()}
+	return topic, c.App.PutPostTopic(c.ID, topic)
+}
+
+// commentToTopic posts the message sent to the post topic as a comment to the
+// post. It returns false if the message is not in the post topic.
+func (c *Chat) commentToTopic(msg *tg.Message) bool {
+	if msg.ReplyToMessage == nil || msg.Text == "" {
+		return false
+	}
+
+	// The message in the topic (that is not a reply to another message) is a
+	// reply to the topic creation message, which ID is the thread ID.
+	topic, err := c.App.GetTopicByThread(c.ID, msg.ReplyToMessage.MessageID)
+	if err != nil {
+		return false
+	}
+
+	p := message.NewPrinter(c.State.Language)
+
+	comment, err := c.frfAPI().AddComment(topic.PostID, msg.Text)
+	if err != nil {
+		errMsg := c.newHTMLMessage(p.Sprintf("Error creating comment: %v", err))
+		errMsg.ReplyToMessageID = msg.MessageID
+		c.ShouldSend(errMsg)
+		return true
+	}
+
+	event := &frf.Event{PostID: topic.PostID}
+	okMsg := c.newHTMLMessage(p.Sprintf(":tada: Comment successfully created!"))
+	okMsg.ReplyToMessageID = msg.MessageID
+	okMsg.ReplyMarkup = c.sentCommentButtons(event, comment.ID)
+	c.ShouldSendAndSave(okMsg, store.SentMsgRec{Event: event})
+	return true
+}
+
+func isTopicNotFound(err error) bool {
+	var tgErr *tg.Error
+	return errors.As(err, &tgErr) &&
+		tgErr.Code == http.StatusBadRequest &&
+		strings.Contains(strings.ToLower(tgErr.Message), "thread not found")
+}
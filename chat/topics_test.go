@@ -0,0 +1,73 @@
+package chat
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/FreeFeed/freefeed-tg-client/store"
+	tg "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	topicsOwnerID = 1
+	groupAdminID  = 2
+	groupMemberID = 3
+)
+
+func newTopicsTestChat() (*Chat, *fakeApp) {
+	app := &fakeApp{admins: map[int64]bool{groupAdminID: true}}
+	return &Chat{
+		ID:  -100,
+		App: app,
+		State: &store.State{
+			ID:            -100,
+			AccessToken:   "token",
+			TopicsMode:    true,
+			TopicsOwnerID: topicsOwnerID,
+		},
+	}, app
+}
+
+func commandUpdate(userID int64, text string) tg.Update {
+	command, _, _ := strings.Cut(text, " ")
+	return tg.Update{Message: &tg.Message{
+		Text:     text,
+		From:     &tg.User{ID: userID},
+		Chat:     &tg.Chat{ID: -100, Type: "supergroup"},
+		Entities: []tg.MessageEntity{{Type: "bot_command", Offset: 0, Length: len(command)}},
+	}}
+}
+
+func TestTopicsModeCommandOfNonOwner(t *testing.T) {
+	c, app := newTopicsTestChat()
+	c.handleCommand(commandUpdate(groupMemberID, "/logout"))
+	assert.False(t, app.deleted)
+	assert.Empty(t, app.sent)
+
+	c.handleCommand(commandUpdate(topicsOwnerID, "/logout"))
+	assert.True(t, app.deleted)
+}
+
+func TestTopicsCommandPermissions(t *testing.T) {
+	t.Run("member", func(t *testing.T) {
+		c, app := newTopicsTestChat()
+		c.handleCommand(commandUpdate(groupMemberID, "/topics off"))
+		assert.True(t, c.State.TopicsMode)
+		assert.Equal(t, int64(topicsOwnerID), c.State.TopicsOwnerID)
+		assert.Empty(t, app.saved)
+		assert.Len(t, app.sent, 1)
+	})
+
+	t.Run("owner", func(t *testing.T) {
+		c, _ := newTopicsTestChat()
+		c.handleCommand(commandUpdate(topicsOwnerID, "/topics off"))
+		assert.False(t, c.State.TopicsMode)
+	})
+
+	t.Run("admin", func(t *testing.T) {
+		c, _ := newTopicsTestChat()
+		c.handleCommand(commandUpdate(groupAdminID, "/topics off"))
+		assert.False(t, c.State.TopicsMode)
+	})
+}
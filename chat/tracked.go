@@ -0,0 +1,235 @@
+package chat
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/FreeFeed/freefeed-tg-client/frf"
+	"github.com/FreeFeed/freefeed-tg-client/types"
+	"github.com/davidmz/go-try"
+	"github.com/enescakir/emoji"
+	tg "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/gofrs/uuid"
+	"golang.org/x/text/message"
+)
+
+// Maximum number of posts in the /tracked list
+const maxTrackedListLength = 20
+
+// How many discussions posts are checked for the comments subscriptions made
+// outside of the bot
+const (
+	discussionsPageSize  = 100
+	maxDiscussionsPages  = 3
+	postsLoadConcurrency = 5
+)
+
+const (
+	untrackPrefix = "untrack:"
+	untrackAll    = "untrackAll"
+)
+
+// setCommentsNotify turns on or off the notifications of all comments to the
+// post. It also turns off the legacy realtime tracking of the post.
+func (c *Chat) setCommentsNotify(postID uuid.UUID, enabled bool) (result bool, err error) {
+	err = try.Func(func() {
+		// Turn off legacy post tracking, if necessary
+		if try.ItVal(c.App.IsPostTracked(c.ID, postID)) {
+			try.It(c.App.UntrackPost(c.ID, postID))
+			// RT unsubscribe
+			try.It(c.App.RTSend(c.ID, "unsubscribe", types.UserSubsPayload{PostIDs: []uuid.UUID{postID}}, nil))
+		}
+
+		result, err = c.frfAPI().NotifyOfAllComments(postID, enabled)
+		if !enabled && frf.IsNotAccessible(err) {
+			// Post is deleted or hidden, nothing to unsubscribe from
+			result, err = false, nil
+		}
+		try.It(err)
+		try.It(c.App.SetPostCommentsNotify(c.ID, postID, result))
+	})()
+	return
+}
+
+// trackedPostIDs returns the IDs of posts with the comment notifications from
+// both the legacy and server-side subscriptions.
+func (c *Chat) trackedPostIDs() ([]uuid.UUID, error) {
+	tracked, err := c.App.TrackedEntities(c.ID)
+	if err != nil {
+		return nil, err
+	}
+	ids := slices.Clone(tracked.PostIDs)
+	for _, id := range tracked.CommentPostIDs {
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// syncTrackedPosts updates the stored comments subscriptions from the recent
+// discussions, so the posts subscribed on the site (or before the bot kept the
+// subscriptions) are listed too. It returns the loaded posts by ID.
+func (c *Chat) syncTrackedPosts() (map[uuid.UUID]*frf.Post, error) {
+	tracked, err := c.App.TrackedEntities(c.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	posts := make(map[uuid.UUID]*frf.Post)
+	for page := 0; page < maxDiscussionsPages; page++ {
+		timeline, err := c.frfAPI().GetDiscussions(page*discussionsPageSize, discussionsPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, post := range timeline.Posts {
+			posts[post.ID] = post
+			if post.NotifyOfAllComments != slices.Contains(tracked.CommentPostIDs, post.ID) {
+				if err := c.App.SetPostCommentsNotify(c.ID, post.ID, post.NotifyOfAllComments); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if timeline.IsLastPage {
+			break
+		}
+	}
+	return posts, nil
+}
+
+// loadPosts loads the posts in parallel. The unavailable posts are nil.
+func (c *Chat) loadPosts(ids []uuid.UUID) []*frf.Post {
+	// The OnUnauthorized handler changes the chat state, so it is not called
+	// from the goroutines
+	api := c.frfAPIWithToken(c.State.AccessToken)
+
+	posts := make([]*frf.Post, len(ids))
+	sem := make(chan struct{}, postsLoadConcurrency)
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		go func(i int, id uuid.UUID) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			posts[i], _ = api.GetPost(id)
+		}(i, id)
+	}
+	wg.Wait()
+	return posts
+}
+
+func (c *Chat) handleTrackedCommand() {
+	text, markup := c.renderTrackedList()
+	msg := c.newRawHTMLMessage(text)
+	msg.ReplyMarkup = markup
+	c.ShouldSend(msg)
+}
+
+// renderTrackedList renders the list of posts with the comment notifications
+// and the buttons to unsubscribe from them.
+func (c *Chat) renderTrackedList() (string, *tg.InlineKeyboardMarkup) {
+	p := message.NewPrinter(c.State.Language)
+
+	loaded, err := c.syncTrackedPosts()
+	if err != nil {
+		// Show at least the known subscriptions
+		c.errorLog().Print(err)
+		loaded = make(map[uuid.UUID]*frf.Post)
+	}
+	ids, err := c.trackedPostIDs()
+	if err != nil {
+		c.errorLog().Print(err)
+		return c.App.Linkify(p.Sprintf("Cannot load the tracked posts: %v", err)), nil
+	}
+	if len(ids) == 0 {
+		return c.App.Linkify(p.Sprintf("You don't get all comments of any post.")), nil
+	}
+
+	var (
+		lines []string
+		rows  [][]tg.InlineKeyboardButton
+	)
+	if len(ids) > maxTrackedListLength {
+		ids = ids[len(ids)-maxTrackedListLength:]
+	}
+	var missing []uuid.UUID
+	for _, id := range ids {
+		if loaded[id] == nil {
+			missing = append(missing, id)
+		}
+	}
+	for i, post := range c.loadPosts(missing) {
+		loaded[missing[i]] = post
+	}
+
+	for i, id := range ids {
+		line := fmt.Sprintf("%d. ", i+1)
+		if post := loaded[id]; post == nil {
+			line += c.App.Linkify(p.Sprintf("Post is not available"))
+		} else {
+			line += fmt.Sprintf(`<a href="https://%s/posts/%s">%s</a>`,
+				c.frfAPI().HostName, id, c.App.Linkify(c.App.ContentOf(post.Digest())))
+			if !post.BumpedAt.IsZero() {
+				lastActivity := post.BumpedAt.Format("2006-01-02")
+				line += ", " + c.App.Linkify(p.Sprintf("last activity %s", lastActivity))
+			}
+		}
+		lines = append(lines, line)
+
+		number := i + 1
+		rows = append(rows, []tg.InlineKeyboardButton{
+			tg.NewInlineKeyboardButtonData(
+				emoji.Parse(p.Sprintf(":no_bell: Unsubscribe from #%d", number)),
+				untrackPrefix+id.String(),
+			),
+		})
+	}
+	rows = append(rows, []tg.InlineKeyboardButton{
+		tg.NewInlineKeyboardButtonData(emoji.Parse(p.Sprintf(":mute: Mute all")), untrackAll),
+	})
+
+	text := c.App.Linkify(p.Sprintf("You get all comments of these posts:")) + "\n\n" + strings.Join(lines, "\n")
+	return text, &tg.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+// handleUntrackCallback unsubscribes from the one post (or from all posts if
+// postID is uuid.Nil) and updates the list.
+func (c *Chat) handleUntrackCallback(cbQuery *tg.CallbackQuery, postID uuid.UUID) {
+	p := message.NewPrinter(c.State.Language)
+
+	ids := []uuid.UUID{postID}
+	if postID == uuid.Nil {
+		var err error
+		if ids, err = c.trackedPostIDs(); c.ShouldOK(err) != nil {
+			return
+		}
+	}
+
+	var failed int
+	for _, id := range ids {
+		if _, err := c.setCommentsNotify(id, false); err != nil {
+			c.errorLog().Printf("Cannot unsubscribe from %s: %v", id, err)
+			failed++
+		}
+	}
+
+	text := p.Sprintf("Done")
+	if failed > 0 {
+		text = p.Sprintf("Cannot unsubscribe from %d posts", failed)
+	}
+	c.ShouldSend(tg.CallbackConfig{CallbackQueryID: cbQuery.ID, Text: text})
+
+	newText, markup := c.renderTrackedList()
+	c.editListMessage(cbQuery.Message.MessageID, newText, markup)
+}
+
+func parseUntrackData(data string) (uuid.UUID, bool) {
+	if data == untrackAll {
+		return uuid.Nil, true
+	}
+	id, ok := parseIDData(untrackPrefix, data)
+	return id, ok && id != uuid.Nil
+}
@@ -4,8 +4,10 @@ import (
 	"github.com/FreeFeed/freefeed-tg-client/frf"
 	"github.com/FreeFeed/freefeed-tg-client/store"
 	"github.com/FreeFeed/freefeed-tg-client/types"
+	"github.com/bluele/gcache"
 	"github.com/davidmz/debug-log"
 	tg "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/gofrs/uuid"
 )
 
 type ID = types.TgChatID
@@ -18,8 +20,14 @@ type App interface {
 	FreeFeedAPI() *frf.API
 	Tg() *tg.BotAPI
 	Send(tg.Chattable) (tg.Message, error)
+	SendToTopic(msg *tg.MessageConfig, threadID int) (tg.Message, error)
+	CreateForumTopic(chatID ID, name string) (int, error)
+	IsChatAdmin(chatID ID, userID int64) (bool, error)
 	Linkify(string) string
+	LinkifyComment(text string, post *frf.Post, commentID uuid.UUID) string
 	ContentOf(string) string
+	SyncSavesEnabled() bool
+	RemoteSavesCache() gcache.Cache
 
 	StartRealtime(ID)
 	StopRealtime(ID)
@@ -0,0 +1,166 @@
+package chat
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/FreeFeed/freefeed-tg-client/frf"
+	"github.com/FreeFeed/freefeed-tg-client/store"
+	"github.com/enescakir/emoji"
+	tg "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/gofrs/uuid"
+	"golang.org/x/text/message"
+)
+
+// Maximum number of saved searches per chat
+const maxWatches = 10
+
+// How many recent search results to check for the new posts
+const watchPageSize = 10
+
+const unwatchPrefix = "unwatch:"
+
+// watchPostEvent is the type of the synthetic event about the new post found by
+// the saved search
+const watchPostEvent = "__watch:post"
+
+// handleWatchCommand handles the "/watch query" command.
+func (c *Chat) handleWatchCommand(args string) {
+	p := message.NewPrinter(c.State.Language)
+
+	query := strings.TrimSpace(args)
+	if query == "" {
+		c.ShouldSend(c.newHTMLMessage(p.Sprintf("Usage: /watch query")))
+		return
+	}
+
+	watches, err := c.App.ListWatches(c.ID)
+	if c.ShouldOK(err) != nil {
+		return
+	}
+	if len(watches) >= maxWatches {
+		c.ShouldSend(c.newHTMLMessage(p.Sprintf(
+			"You cannot have more than %d saved searches. Use /watches to remove some of them.",
+			maxWatches,
+		)))
+		return
+	}
+
+	// Check the query and remember the newest post
+	watch := store.Watch{ID: uuid.Must(uuid.NewV4()), Query: query}
+	results, err := c.frfAPI().Search(query, 0, 1)
+	if err != nil {
+		c.ShouldSend(c.newHTMLMessage(p.Sprintf(":alien: Cannot search: %v", err)))
+		return
+	}
+	watch.LastPostAt = time.Now()
+	if len(results.Posts) > 0 {
+		watch.LastPostAt = results.Posts[0].CreatedAt.Time
+	}
+
+	if c.ShouldOK(c.App.AddWatch(c.ID, watch)) != nil {
+		return
+	}
+	c.ShouldSend(c.newHTMLMessage(p.Sprintf(
+		":mag: Search is saved. The bot will notify you about the new posts matching \"%s\".",
+		query,
+	)))
+}
+
+// handleWatchesCommand lists the saved searches with the buttons to remove
+// them.
+func (c *Chat) handleWatchesCommand() {
+	p := message.NewPrinter(c.State.Language)
+
+	watches, err := c.App.ListWatches(c.ID)
+	if c.ShouldOK(err) != nil {
+		return
+	}
+	if len(watches) == 0 {
+		c.ShouldSend(c.newHTMLMessage(p.Sprintf("You have no saved searches. Use /watch to add one.")))
+		return
+	}
+
+	msg := c.newHTMLMessage(p.Sprintf("Your saved searches (tap to remove):"))
+	msg.ReplyMarkup = c.watchesButtons(watches)
+	c.ShouldSend(msg)
+}
+
+func (c *Chat) watchesButtons(watches []store.Watch) tg.InlineKeyboardMarkup {
+	var rows [][]tg.InlineKeyboardButton
+	for _, w := range watches {
+		rows = append(rows, []tg.InlineKeyboardButton{
+			tg.NewInlineKeyboardButtonData(emoji.Parse(":x: ")+w.Query, unwatchPrefix+w.ID.String()),
+		})
+	}
+	return tg.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+// handleUnwatchCallback removes the saved search and updates the list.
+func (c *Chat) handleUnwatchCallback(cbQuery *tg.CallbackQuery, watchID uuid.UUID) {
+	p := message.NewPrinter(c.State.Language)
+
+	text := p.Sprintf("Saved search is removed")
+	if err := c.App.DeleteWatch(c.ID, watchID); err != nil {
+		text = p.Sprintf("Cannot remove saved search: %v", err)
+	}
+	c.ShouldSend(tg.CallbackConfig{CallbackQueryID: cbQuery.ID, Text: text})
+
+	watches, err := c.App.ListWatches(c.ID)
+	if c.ShouldOK(err) != nil {
+		return
+	}
+	if len(watches) == 0 {
+		c.ShouldSend(tg.NewEditMessageText(c.ID, cbQuery.Message.MessageID,
+			p.Sprintf("You have no saved searches. Use /watch to add one.")))
+		return
+	}
+	c.ShouldSend(tg.NewEditMessageReplyMarkup(c.ID, cbQuery.Message.MessageID, c.watchesButtons(watches)))
+}
+
+// CheckWatch runs the saved search and delivers the new posts. It updates the
+// watch.LastPostAt.
+func (c *Chat) CheckWatch(watch *store.Watch) error {
+	results, err := c.frfAPI().Search(watch.Query, 0, watchPageSize)
+	if err != nil {
+		return err
+	}
+
+	var events []*frf.Event
+	for _, post := range newWatchPosts(watch, results.Posts) {
+		events = append(events, &frf.Event{
+			Type:          watchPostEvent,
+			PostID:        post.ID,
+			Post:          post,
+			CreatedUserID: post.CreatedBy,
+			CreatedUser:   post.Author,
+			WatchQuery:    watch.Query,
+		})
+	}
+
+	if len(events) > 0 {
+		c.ProcessEvents(events)
+	}
+	return nil
+}
+
+// newWatchPosts returns the posts created after the last check, from the
+// oldest to the newest, and updates the watch.LastPostAt.
+func newWatchPosts(watch *store.Watch, posts []*frf.Post) []*frf.Post {
+	var newPosts []*frf.Post
+	lastPostAt := watch.LastPostAt
+	for _, post := range posts {
+		if !post.CreatedAt.After(watch.LastPostAt) {
+			continue
+		}
+		newPosts = append(newPosts, post)
+		if post.CreatedAt.After(lastPostAt) {
+			lastPostAt = post.CreatedAt.Time
+		}
+	}
+	watch.LastPostAt = lastPostAt
+
+	slices.SortFunc(newPosts, func(a, b *frf.Post) int { return a.CreatedAt.Compare(b.CreatedAt.Time) })
+	return newPosts
+}
@@ -0,0 +1,36 @@
+package chat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FreeFeed/freefeed-tg-client/frf"
+	"github.com/FreeFeed/freefeed-tg-client/store"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewWatchPosts(t *testing.T) {
+	now := time.Now().Truncate(time.Millisecond)
+	newPost := func(age time.Duration) *frf.Post {
+		return &frf.Post{
+			ID:        uuid.Must(uuid.NewV4()),
+			CreatedAt: frf.Timestamp{Time: now.Add(-age)},
+		}
+	}
+	// Search results are ordered by bump time, not by creation time
+	p1, p2, p3 := newPost(3*time.Minute), newPost(2*time.Minute), newPost(time.Minute)
+	posts := []*frf.Post{p2, p3, p1}
+
+	t.Run("new posts", func(t *testing.T) {
+		watch := &store.Watch{LastPostAt: p1.CreatedAt.Time}
+		assert.Equal(t, []*frf.Post{p2, p3}, newWatchPosts(watch, posts))
+		assert.Equal(t, p3.CreatedAt.Time, watch.LastPostAt)
+	})
+
+	t.Run("bumped old post", func(t *testing.T) {
+		watch := &store.Watch{LastPostAt: p3.CreatedAt.Time}
+		assert.Empty(t, newWatchPosts(watch, posts))
+		assert.Equal(t, p3.CreatedAt.Time, watch.LastPostAt)
+	})
+}
@@ -4,8 +4,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"slices"
 	"time"
 
 	"github.com/davidmz/go-try"
@@ -19,6 +22,8 @@ type API struct {
 	HostName    string
 	AccessToken string
 	UserAgent   string
+	// OnUnauthorized is called when the server rejects the access token
+	OnUnauthorized func()
 }
 
 // GetMe returns the basic information about the current user
@@ -30,6 +35,15 @@ func (a *API) GetMe() (*User, error) {
 	return resp.User, err
 }
 
+// GetCurrentAppToken returns the metadata of the token used for the requests
+func (a *API) GetCurrentAppToken() (*AppToken, error) {
+	resp := &struct {
+		Token *AppToken `json:"token"`
+	}{}
+	err := a.request("GET", "/v4/app-tokens/current", nil, resp)
+	return resp.Token, err
+}
+
 func (a *API) GetEvents() ([]*Event, error) {
 	resp := &struct {
 		Events []*Event `json:"Notifications"`
@@ -65,27 +79,154 @@ func (a *API) GetPost(postID uuid.UUID) (*Post, error) {
 		}
 		TargetFeeds []Feed `json:"subscriptions"`
 		Comments    []Comment
+		Users       []*User
+		Subscribers []*User
 	}{}
 	err := a.request("GET", "/v2/posts/"+postID.String()+"?maxComments=all", nil, resp)
 	if err == nil {
-		for _, feedID := range resp.Posts.PostedTo {
-			for _, feed := range resp.TargetFeeds {
-				if feed.ID == feedID {
-					resp.Posts.Recipients = append(resp.Posts.Recipients, feed)
-				}
+		post := &resp.Posts.Post
+		post.fillRecipients(resp.Posts.PostedTo, resp.TargetFeeds)
+
+		accByID := make(map[uuid.UUID]*User)
+		for _, a := range resp.Users {
+			accByID[a.ID] = a
+		}
+		for _, a := range resp.Subscribers {
+			accByID[a.ID] = a
+		}
+
+		post.Author = accByID[post.CreatedBy]
+		for i := range resp.Comments {
+			resp.Comments[i].Author = accByID[resp.Comments[i].CreatedBy]
+		}
+		post.Comments = resp.Comments
+		for _, f := range post.Recipients {
+			if acc := accByID[f.OwnerID]; acc != nil && acc.IsGroup() && !slices.Contains(post.Groups, acc) {
+				post.Groups = append(post.Groups, acc)
 			}
 		}
-		resp.Posts.Post.Comments = resp.Comments
 	}
 	return &resp.Posts.Post, err
 }
 
+// GetUser returns the user or group by its username
+func (a *API) GetUser(userName string) (*User, error) {
+	resp := &struct {
+		User *User `json:"users"`
+	}{}
+	err := a.request("GET", "/v1/users/"+url.PathEscape(userName), nil, resp)
+	return resp.User, err
+}
+
+// GetTimeline returns the page of the feed posts. The feedPath is the path
+// after the '/v2/timelines/' prefix, e.g. "home" or "filter/directs". The zero
+// limit means the server default.
+func (a *API) GetTimeline(feedPath string, offset, limit int) (*Timeline, error) {
+	return a.getPostsPage("/v2/timelines/"+feedPath+"?", offset, limit)
+}
+
+// Search returns the page of posts found by the query. The query may contain
+// the FreeFeed search operators like "from:" or "in:".
+func (a *API) Search(query string, offset, limit int) (*Timeline, error) {
+	return a.getPostsPage("/v2/search?qs="+url.QueryEscape(query)+"&", offset, limit)
+}
+
+func (a *API) getPostsPage(uri string, offset, limit int) (*Timeline, error) {
+	resp := &struct {
+		Posts []struct {
+			Post
+			PostedTo []uuid.UUID
+		}
+		TargetFeeds []Feed `json:"subscriptions"`
+		Users       []*User
+		IsLastPage  bool
+		Timelines   *struct{ ID uuid.UUID }
+	}{}
+	uri += fmt.Sprintf("offset=%d", offset)
+	if limit > 0 {
+		uri += fmt.Sprintf("&limit=%d", limit)
+	}
+	err := a.request("GET", uri, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	accByID := make(map[uuid.UUID]*User)
+	for _, a := range resp.Users {
+		accByID[a.ID] = a
+	}
+
+	timeline := &Timeline{IsLastPage: resp.IsLastPage}
+	if resp.Timelines != nil {
+		timeline.FeedID = resp.Timelines.ID
+	}
+	for i := range resp.Posts {
+		post := &resp.Posts[i].Post
+		post.fillRecipients(resp.Posts[i].PostedTo, resp.TargetFeeds)
+		post.Author = accByID[post.CreatedBy]
+		timeline.Posts = append(timeline.Posts, post)
+	}
+	return timeline, nil
+}
+
+// GetDiscussions returns the page of the user's discussions: the posts the
+// user created, commented, liked or subscribed to the comments of.
+func (a *API) GetDiscussions(offset, limit int) (*Timeline, error) {
+	return a.getPostsPage("/v2/timelines/filter/discussions?with-my-posts=yes&", offset, limit)
+}
+
+// GetDirects returns the latest direct messages of the current user
+func (a *API) GetDirects() ([]*Post, error) {
+	timeline, err := a.GetTimeline("filter/directs", 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	return timeline.Posts, nil
+}
+
 func (a *API) GetPostID(shortID string) (uuid.UUID, error) {
 	resp := &struct{ Posts struct{ Post } }{}
 	err := a.request("GET", "/v2/posts/"+shortID, nil, resp)
 	return resp.Posts.Post.ID, err
 }
 
+// GetSubscriptionRequests returns the pending subscription requests to the
+// current user and to the groups they administer.
+func (a *API) GetSubscriptionRequests() (*SubscriptionRequests, error) {
+	resp := &struct {
+		Users struct {
+			SubscriptionRequests []uuid.UUID
+		}
+		Requests      []*User
+		ManagedGroups []struct {
+			User
+			Requests []*User
+		}
+	}{}
+	if err := a.request("GET", "/v1/users/whoami", nil, resp); err != nil {
+		return nil, err
+	}
+
+	accByID := make(map[uuid.UUID]*User)
+	for _, a := range resp.Requests {
+		accByID[a.ID] = a
+	}
+
+	result := new(SubscriptionRequests)
+	for _, id := range resp.Users.SubscriptionRequests {
+		if acc := accByID[id]; acc != nil {
+			result.Users = append(result.Users, acc)
+		}
+	}
+	for i := range resp.ManagedGroups {
+		g := &resp.ManagedGroups[i]
+		if len(g.Requests) > 0 {
+			result.Groups = append(result.Groups, GroupRequests{Group: &g.User, Users: g.Requests})
+		}
+	}
+	return result, nil
+}
+
 func (a *API) AcceptSubscriptionRequest(userName string) error {
 	return a.request("POST", "/v1/users/acceptRequest/"+userName, &struct{}{}, nil)
 }
@@ -118,7 +259,44 @@ func (a *API) NotifyOfAllComments(postID uuid.UUID, enabled bool) (bool, error)
 		Enabled bool `json:"enabled"`
 	}{enabled}
 	err := a.request("POST", "/v1/posts/"+postID.String()+"/notifyOfAllComments", req, resp)
-	return resp.Posts.NotifyOfAllComments, err
+	if err != nil {
+		return false, err
+	}
+	return resp.Posts.NotifyOfAllComments, nil
+}
+
+// DeleteComment deletes the comment. Group admins can delete comments to the
+// posts in their groups.
+func (a *API) DeleteComment(commentID uuid.UUID) error {
+	return a.request("DELETE", "/v1/comments/"+commentID.String(), nil, nil)
+}
+
+// RemovePostFromGroup removes the post from the group feed. The current user
+// must be the group admin.
+func (a *API) RemovePostFromGroup(postID uuid.UUID, groupName string) error {
+	return a.request("DELETE", "/v1/posts/"+postID.String()+"?fromFeed="+url.QueryEscape(groupName), nil, nil)
+}
+
+// BlockUserInGroup disallows user to post and comment in the group. The
+// current user must be the group admin.
+func (a *API) BlockUserInGroup(groupName, userName string) error {
+	return a.request("POST", "/v2/groups/"+url.PathEscape(groupName)+"/block/"+url.PathEscape(userName), &struct{}{}, nil)
+}
+
+// SavePost adds the post to the user's saved posts on the server.
+func (a *API) SavePost(postID uuid.UUID) error {
+	return a.request("POST", "/v1/posts/"+postID.String()+"/save", &struct{}{}, nil)
+}
+
+// UnsavePost removes the post from the user's saved posts on the server.
+func (a *API) UnsavePost(postID uuid.UUID) error {
+	return a.request("DELETE", "/v1/posts/"+postID.String()+"/save", nil, nil)
+}
+
+// GetSavedPosts returns the page of the user's saved posts on the server, the
+// most recently saved first.
+func (a *API) GetSavedPosts(offset, limit int) (*Timeline, error) {
+	return a.GetTimeline("filter/saves", offset, limit)
 }
 
 func (a *API) AddComment(postID uuid.UUID, text string) (*Comment, error) {
@@ -129,6 +307,16 @@ func (a *API) AddComment(postID uuid.UUID, text string) (*Comment, error) {
 	return resp.Comment, err
 }
 
+// CreatePost creates a new post in the given feeds. To create a direct message,
+// pass the usernames of the recipients as feeds.
+func (a *API) CreatePost(feeds []string, text string) (*Post, error) {
+	resp := &struct {
+		Post *Post `json:"posts"`
+	}{}
+	err := a.request("POST", "/v1/posts", newCreatePostRequest(feeds, text), resp)
+	return resp.Post, err
+}
+
 ////
 
 func (a *API) request(method string, uri string, reqObj interface{}, respObj interface{}) (err error) {
@@ -160,7 +348,12 @@ func (a *API) request(method string, uri string, reqObj interface{}, respObj int
 	resp := try.ItVal(http.DefaultClient.Do(req))
 	defer resp.Body.Close()
 
-	try.It(errorFromResponse(resp))
+	if err := errorFromResponse(resp); err != nil {
+		if IsUnauthorized(err) && a.OnUnauthorized != nil {
+			a.OnUnauthorized()
+		}
+		try.Throw(err)
+	}
 
 	if respObj != nil {
 		data := try.ItVal(io.ReadAll(resp.Body))
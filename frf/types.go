@@ -2,11 +2,15 @@ package frf
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/gofrs/uuid"
@@ -21,12 +25,24 @@ type User struct {
 	ID   uuid.UUID `json:"id"`
 	Name string    `json:"username"`
 	Type string    `json:"type"`
+	// Administrators of the group, if the user is a group
+	Administrators []uuid.UUID `json:"administrators,omitempty"`
 }
 
 func (u *User) String() string {
 	return "@" + u.Name
 }
 
+func (u *User) IsGroup() bool {
+	return u.Type == "group"
+}
+
+// IsAdmin returns true if the user is a group and userID is one of its
+// administrators.
+func (u *User) IsAdmin(userID uuid.UUID) bool {
+	return u.IsGroup() && slices.Contains(u.Administrators, userID)
+}
+
 type Event struct {
 	ID           uuid.UUID `json:"eventId"`
 	Type         string    `json:"event_type"`
@@ -44,8 +60,11 @@ type Event struct {
 	CreatedUser  *User
 	Group        *User
 	PostAuthor   *User
-	Post         *Post    `json:"-"`
-	Comment      *Comment `json:"-"`
+	// Query of the saved search that found the post, for the bot's own
+	// watch events
+	WatchQuery string   `json:"watch_query,omitempty"`
+	Post       *Post    `json:"-"`
+	Comment    *Comment `json:"-"`
 }
 
 func (e *Event) LoadPost(api *API) error {
@@ -83,15 +102,82 @@ type Feed struct {
 type Post struct {
 	ID                  uuid.UUID
 	Body                string
+	CreatedBy           uuid.UUID
+	CreatedAt           Timestamp
+	BumpedAt            Timestamp // time of the last activity in the post
 	Recipients          []Feed
 	NotifyOfAllComments bool
+	Author              *User     `json:"-"`
 	Comments            []Comment `json:"-"`
+	// Groups the post is posted to
+	Groups []*User `json:"-"`
+}
+
+// Timestamp is the time in API responses, encoded as a string with the number
+// of milliseconds since the epoch.
+type Timestamp struct{ time.Time }
+
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	if str == "" {
+		t.Time = time.Time{}
+		return nil
+	}
+	ms, err := strconv.ParseInt(str, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp %q: %w", str, err)
+	}
+	t.Time = time.UnixMilli(ms)
+	return nil
+}
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte(`""`), nil
+	}
+	return json.Marshal(strconv.FormatInt(t.UnixMilli(), 10))
+}
+
+// SubscriptionRequests are the pending requests to the current user and to
+// their groups
+type SubscriptionRequests struct {
+	Users  []*User
+	Groups []GroupRequests
+}
+
+type GroupRequests struct {
+	Group *User
+	Users []*User
+}
+
+// Timeline is a page of the feed posts
+type Timeline struct {
+	// FeedID is the ID of the timeline feed, it is uuid.Nil for the search
+	// results
+	FeedID     uuid.UUID
+	Posts      []*Post
+	IsLastPage bool
+}
+
+func (p *Post) fillRecipients(postedTo []uuid.UUID, feeds []Feed) {
+	for _, feedID := range postedTo {
+		for _, feed := range feeds {
+			if feed.ID == feedID {
+				p.Recipients = append(p.Recipients, feed)
+			}
+		}
+	}
 }
 
 type Comment struct {
 	ID         uuid.UUID
 	Body       string
 	HasOwnLike bool
+	CreatedBy  uuid.UUID
+	Author     *User `json:"-"`
 }
 
 func (p *Post) InNamedFeedOf(name string, ownerID uuid.UUID) bool {
@@ -133,6 +219,14 @@ func (p *Post) Digest() string {
 	return s
 }
 
+// AppToken is the metadata of the application token
+type AppToken struct {
+	ID        uuid.UUID
+	Title     string
+	Scopes    []string
+	ExpiresAt time.Time
+}
+
 // Error is the API error
 type Error struct {
 	Err            string
@@ -149,6 +243,21 @@ func (e *Error) Error() string {
 
 func (e *Error) String() string { return e.Error() }
 
+// IsUnauthorized returns true if the error means that the access token is
+// invalid, revoked or expired.
+func IsUnauthorized(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.HTTPStatusCode == http.StatusUnauthorized
+}
+
+// IsNotAccessible returns true if the requested object is deleted or not
+// accessible for the current user.
+func IsNotAccessible(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) &&
+		(apiErr.HTTPStatusCode == http.StatusNotFound || apiErr.HTTPStatusCode == http.StatusForbidden)
+}
+
 func errorFromResponse(resp *http.Response) error {
 	if resp.StatusCode < http.StatusBadRequest {
 		return nil
@@ -218,6 +327,30 @@ func newAddCommentRequest(postID uuid.UUID, body string) *addCommentRequest {
 	return req
 }
 
+type createPostRequest struct {
+	Post struct {
+		Body string `json:"body"`
+	} `json:"post"`
+	Meta struct {
+		Feeds []string `json:"feeds"`
+	} `json:"meta"`
+}
+
+func newCreatePostRequest(feeds []string, body string) *createPostRequest {
+	req := new(createPostRequest)
+	req.Post.Body = body
+	req.Meta.Feeds = feeds
+	return req
+}
+
+type NewPostEvent struct {
+	Posts struct {
+		ID        uuid.UUID
+		CreatedBy uuid.UUID
+	}
+	Users []*User
+}
+
 type NewCommentEvent struct {
 	Comments struct {
 		ID        uuid.UUID
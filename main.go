@@ -35,6 +35,10 @@ func main() {
 		dataDir      string
 		debugSources string
 		noContent    bool
+		syncSaves    bool
+		inactiveTTL  time.Duration
+		topicTTL     time.Duration
+		messagesTTL  time.Duration
 	)
 
 	flag.StringVar(&tgToken, "token", "", "Telegram bot token")
@@ -46,6 +50,10 @@ func main() {
 		"User-Agent for backend requests")
 	flag.StringVar(&debugSources, "debug", "", "Debug sources, set to '*' to see all messages")
 	flag.BoolVar(&noContent, "no-content", false, "Do not include post/comment content into the TG messages")
+	flag.BoolVar(&syncSaves, "sync-saves", false, "Sync the bookmarked posts with the FreeFeed saved posts")
+	flag.DurationVar(&inactiveTTL, "inactive-ttl", 30*24*time.Hour, "Delete data of users who blocked the bot after this period")
+	flag.DurationVar(&topicTTL, "topic-ttl", 14*24*time.Hour, "Delete forum topics of posts without updates after this period")
+	flag.DurationVar(&messagesTTL, "messages-ttl", 90*24*time.Hour, "Forget the data of sent messages (used by their buttons) after this period")
 	flag.Parse()
 
 	if tgToken == "" && tgTokenFile == "" {
@@ -81,11 +89,15 @@ func main() {
 	a := &app.App{
 		DebugLogger:  debugLogger,
 		ErrorLogger:  errorLogger,
-		Store:        store.NewFsStore(dataDir),
+		Store:        store.NewFsStore(dataDir, store.FsSentRecordsTTL(messagesTTL)),
 		TgAPI:        tgBot,
 		FreeFeedHost: frfHost,
 		UserAgent:    userAgent,
 		NoContent:    noContent,
+		SyncSaves:    syncSaves,
+
+		InactiveChatTTL: inactiveTTL,
+		PostTopicTTL:    topicTTL,
 	}
 
 	handleStopSignals(a.Close, debugLogger)
@@ -1,10 +1,19 @@
 package store
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"path"
+	"slices"
+	"time"
 
 	"github.com/FreeFeed/freefeed-tg-client/frf"
 	"github.com/FreeFeed/freefeed-tg-client/types"
+	"github.com/davidmz/go-try"
+	"github.com/gofrs/uuid"
 )
 
 type SentMsgRec struct {
@@ -12,30 +21,356 @@ type SentMsgRec struct {
 	Event     *frf.Event
 	// .ReplyToMessage.MessageID
 	ReplyToID int
+	// Message text was truncated and can be expanded
+	Truncated bool
+	// The rest of the truncated text, split to the message-sized parts
+	Rest []string `json:",omitempty"`
 }
 
-func (s *fsStore) GetMsgRec(chatID types.TgChatID, messageID int) (SentMsgRec, error) {
+// The sent message records are kept in the append-only log file, one JSON
+// entry per line. The in-memory index maps the message IDs to the positions of
+// their entries in the file, so the lookup reads only one line. The replaced,
+// evicted and expired entries stay in the file until the compaction.
+
+type msgLogEntry struct {
+	SentAt time.Time
+	Record SentMsgRec
+}
+
+type msgIndexEntry struct {
+	offset int64
+	length int
+	sentAt time.Time
+	postID uuid.UUID
+}
+
+type msgIndex struct {
+	entries map[int]msgIndexEntry
+	byPost  map[uuid.UUID][]int
+	// Message IDs in the order of addition
+	order []int
+	// Size of the log file
+	size int64
+	// Number of the dead entries in the log file
+	garbage int
+}
+
+func newMsgIndex() *msgIndex {
+	return &msgIndex{
+		entries: make(map[int]msgIndexEntry),
+		byPost:  make(map[uuid.UUID][]int),
+	}
+}
+
+func (idx *msgIndex) put(messageID int, entry msgIndexEntry) {
+	if old, ok := idx.entries[messageID]; ok {
+		idx.garbage++
+		idx.unlinkPost(old.postID, messageID)
+	} else {
+		idx.order = append(idx.order, messageID)
+	}
+	idx.entries[messageID] = entry
+	if entry.postID != uuid.Nil {
+		idx.byPost[entry.postID] = append(idx.byPost[entry.postID], messageID)
+	}
+}
+
+func (idx *msgIndex) evictOldest() {
+	messageID := idx.order[0]
+	idx.order = idx.order[1:]
+	if entry, ok := idx.entries[messageID]; ok {
+		delete(idx.entries, messageID)
+		idx.unlinkPost(entry.postID, messageID)
+		idx.garbage++
+	}
+}
+
+func (idx *msgIndex) unlinkPost(postID uuid.UUID, messageID int) {
+	if postID == uuid.Nil {
+		return
+	}
+	ids := slices.DeleteFunc(idx.byPost[postID], func(id int) bool { return id == messageID })
+	if len(ids) == 0 {
+		delete(idx.byPost, postID)
+	} else {
+		idx.byPost[postID] = ids
+	}
+}
+
+func (s *fsStore) isMsgRecExpired(entry msgIndexEntry, now time.Time) bool {
+	return s.sentRecordsTTL > 0 && now.Sub(entry.sentAt) > s.sentRecordsTTL
+}
+
+func (s *fsStore) GetMsgRec(chatID types.TgChatID, messageID int) (_ SentMsgRec, outErr error) {
+	defer try.HandleAs(&outErr)
+
+	lk, release := s.fileLock(chatID)
+	defer release()
+	lk.Lock()
+	defer lk.Unlock()
+
+	idx := try.ItVal(s.msgIndex(chatID))
+	entry, ok := idx.entries[messageID]
+	if !ok || s.isMsgRecExpired(entry, time.Now()) {
+		return SentMsgRec{}, fmt.Errorf("cannot find event data for this message: %w", ErrNotFound)
+	}
+
+	f := try.ItVal(os.Open(path.Join(s.stateDirPath(chatID), sentEventsLogFile)))
+	defer f.Close()
+
+	return try.ItVal(readMsgLogEntry(f, entry)).Record, nil
+}
+
+// ListPostMsgRecs returns the records of the messages about the given post,
+// ordered by the message ID.
+func (s *fsStore) ListPostMsgRecs(chatID types.TgChatID, postID uuid.UUID) (_ []SentMsgRec, outErr error) {
+	defer try.HandleAs(&outErr)
+
+	lk, release := s.fileLock(chatID)
+	defer release()
+	lk.Lock()
+	defer lk.Unlock()
+
+	idx := try.ItVal(s.msgIndex(chatID))
+	messageIDs := slices.Clone(idx.byPost[postID])
+	if len(messageIDs) == 0 {
+		return nil, nil
+	}
+	slices.Sort(messageIDs)
+
+	f := try.ItVal(os.Open(path.Join(s.stateDirPath(chatID), sentEventsLogFile)))
+	defer f.Close()
+
+	now := time.Now()
 	var records []SentMsgRec
+	for _, messageID := range messageIDs {
+		entry := idx.entries[messageID]
+		if s.isMsgRecExpired(entry, now) {
+			continue
+		}
+		records = append(records, try.ItVal(readMsgLogEntry(f, entry)).Record)
+	}
+	return records, nil
+}
+
+func (s *fsStore) PutMsgRec(chatID types.TgChatID, rec SentMsgRec) (outErr error) {
+	defer try.HandleAs(&outErr)
+
+	lk, release := s.fileLock(chatID)
+	defer release()
+	lk.Lock()
+	defer lk.Unlock()
+
+	idx := try.ItVal(s.msgIndex(chatID))
+
+	// The replaced record keeps its original age
+	sentAt := time.Now()
+	if old, ok := idx.entries[rec.MessageID]; ok {
+		sentAt = old.sentAt
+	}
+
+	line := try.ItVal(json.Marshal(msgLogEntry{SentAt: sentAt, Record: rec}))
+	line = append(line, '\n')
+
+	try.It(os.MkdirAll(s.stateDirPath(chatID), dirsPerm))
+	filePath := path.Join(s.stateDirPath(chatID), sentEventsLogFile)
+	f := try.ItVal(os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, filesPerm))
+	_, err := f.Write(line)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		// The file may have a partially written line now, the index will be
+		// rebuilt (and the line cut off) on the next access.
+		s.dropMsgIndex(chatID)
+		try.Throw(err)
+	}
 
-	if err := s.loadData(chatID, sentEventsFile, &records); err != nil {
-		return SentMsgRec{}, err
+	entry := msgIndexEntry{offset: idx.size, length: len(line), sentAt: sentAt}
+	if rec.Event != nil {
+		entry.postID = rec.Event.PostID
 	}
+	idx.put(rec.MessageID, entry)
+	idx.size += int64(len(line))
 
-	for _, record := range records {
-		if record.MessageID == messageID {
-			return record, nil
+	for s.maxSentRecords > 0 && len(idx.order) > s.maxSentRecords {
+		idx.evictOldest()
+	}
+
+	return nil
+}
+
+// CompactMsgRecs removes the expired records and rewrites the log file if it
+// has the dead entries. It returns the number of the removed records.
+func (s *fsStore) CompactMsgRecs(chatID types.TgChatID, now time.Time) (_ int, outErr error) {
+	defer try.HandleAs(&outErr)
+
+	lk, release := s.fileLock(chatID)
+	defer release()
+	lk.Lock()
+	defer lk.Unlock()
+
+	idx := try.ItVal(s.msgIndex(chatID))
+
+	removed := 0
+	var live []int
+	for _, messageID := range idx.order {
+		if s.isMsgRecExpired(idx.entries[messageID], now) {
+			removed++
+		} else {
+			live = append(live, messageID)
+		}
+	}
+	if removed == 0 && idx.garbage == 0 {
+		return 0, nil
+	}
+
+	filePath := path.Join(s.stateDirPath(chatID), sentEventsLogFile)
+	data := try.ItVal(os.ReadFile(filePath))
+
+	newIdx := newMsgIndex()
+	var buf bytes.Buffer
+	for _, messageID := range live {
+		entry := idx.entries[messageID]
+		line := data[entry.offset : entry.offset+int64(entry.length)]
+		entry.offset = int64(buf.Len())
+		buf.Write(line)
+		newIdx.put(messageID, entry)
+	}
+	newIdx.size = int64(buf.Len())
+
+	if len(live) == 0 {
+		try.It(os.Remove(filePath))
+	} else {
+		try.It(writeFileAtomic(filePath, buf.Bytes()))
+	}
+
+	s.msgIndexesLock.Lock()
+	s.msgIndexes[chatID] = newIdx
+	s.msgIndexesLock.Unlock()
+
+	return removed, nil
+}
+
+// msgIndex returns the index of the sent message records of the chat, loading
+// it if necessary. The chat file lock must be held.
+func (s *fsStore) msgIndex(chatID tKey) (*msgIndex, error) {
+	s.msgIndexesLock.Lock()
+	idx, ok := s.msgIndexes[chatID]
+	s.msgIndexesLock.Unlock()
+	if ok {
+		return idx, nil
+	}
+
+	idx, err := s.loadMsgIndex(chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.msgIndexesLock.Lock()
+	s.msgIndexes[chatID] = idx
+	s.msgIndexesLock.Unlock()
+	return idx, nil
+}
+
+func (s *fsStore) dropMsgIndex(chatID tKey) {
+	s.msgIndexesLock.Lock()
+	delete(s.msgIndexes, chatID)
+	s.msgIndexesLock.Unlock()
+}
+
+func (s *fsStore) loadMsgIndex(chatID tKey) (_ *msgIndex, outErr error) {
+	defer try.HandleAs(&outErr)
+
+	filePath := path.Join(s.stateDirPath(chatID), sentEventsLogFile)
+	data, err := os.ReadFile(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return s.migrateMsgRecs(chatID)
+	}
+	try.It(err)
+
+	idx := newMsgIndex()
+	for len(data) > 0 {
+		n := bytes.IndexByte(data, '\n')
+		if n < 0 {
+			// Incomplete last line of the interrupted write
+			try.It(os.Truncate(filePath, idx.size))
+			break
 		}
+
+		var logEntry msgLogEntry
+		if err := json.Unmarshal(data[:n], &logEntry); err == nil {
+			entry := msgIndexEntry{offset: idx.size, length: n + 1, sentAt: logEntry.SentAt}
+			if logEntry.Record.Event != nil {
+				entry.postID = logEntry.Record.Event.PostID
+			}
+			idx.put(logEntry.Record.MessageID, entry)
+		} else {
+			idx.garbage++
+		}
+
+		idx.size += int64(n + 1)
+		data = data[n+1:]
 	}
-	return SentMsgRec{}, fmt.Errorf("cannot find event data for this message: %w", ErrNotFound)
+
+	for s.maxSentRecords > 0 && len(idx.order) > s.maxSentRecords {
+		idx.evictOldest()
+	}
+	return idx, nil
 }
 
-func (s *fsStore) PutMsgRec(chatID types.TgChatID, rec SentMsgRec) error {
+// migrateMsgRecs converts the legacy sentEventsFile to the log file. The
+// legacy records have no sending time, so they are treated as just sent.
+func (s *fsStore) migrateMsgRecs(chatID tKey) (_ *msgIndex, outErr error) {
+	defer try.HandleAs(&outErr)
+
+	idx := newMsgIndex()
+
+	legacyPath := path.Join(s.stateDirPath(chatID), sentEventsFile)
+	data, err := os.ReadFile(legacyPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return idx, nil
+	}
+	try.It(err)
+
 	var records []SentMsgRec
-	return s.updateData(chatID, sentEventsFile, &records, func() error {
-		records = append(records, rec)
-		if len(records) > s.maxSentRecords {
-			records = records[len(records)-s.maxSentRecords:]
+	try.It(unmarshalFile(legacyPath, data, &records))
+	if s.maxSentRecords > 0 && len(records) > s.maxSentRecords {
+		records = records[len(records)-s.maxSentRecords:]
+	}
+
+	now := time.Now()
+	var buf bytes.Buffer
+	for _, rec := range records {
+		line := try.ItVal(json.Marshal(msgLogEntry{SentAt: now, Record: rec}))
+		line = append(line, '\n')
+		entry := msgIndexEntry{offset: int64(buf.Len()), length: len(line), sentAt: now}
+		if rec.Event != nil {
+			entry.postID = rec.Event.PostID
 		}
-		return nil
-	})
+		buf.Write(line)
+		idx.put(rec.MessageID, entry)
+	}
+	idx.size = int64(buf.Len())
+
+	filePath := path.Join(s.stateDirPath(chatID), sentEventsLogFile)
+	try.It(writeFileAtomic(filePath, buf.Bytes()))
+	try.It(os.Remove(legacyPath))
+
+	return idx, nil
+}
+
+func readMsgLogEntry(f *os.File, entry msgIndexEntry) (msgLogEntry, error) {
+	var logEntry msgLogEntry
+	buf := make([]byte, entry.length)
+	if _, err := f.ReadAt(buf, entry.offset); err != nil {
+		return logEntry, fmt.Errorf("cannot read sent message record: %w", err)
+	}
+	if err := json.Unmarshal(buf, &logEntry); err != nil {
+		return logEntry, fmt.Errorf("cannot parse sent message record: %w", err)
+	}
+	return logEntry, nil
 }
@@ -0,0 +1,55 @@
+package store
+
+import (
+	"fmt"
+
+	"github.com/FreeFeed/freefeed-tg-client/types"
+	"github.com/gofrs/uuid"
+)
+
+type FilterMode string
+
+const (
+	// Notify only about the events matching at least one of such filters
+	FilterOnly FilterMode = "only"
+	// Never notify about the events matching the filter
+	FilterNever FilterMode = "never"
+	// Show the matched filter in the notification header
+	FilterHighlight FilterMode = "highlight"
+)
+
+// Filter is the regular expression rule for the post and comment texts.
+type Filter struct {
+	ID      uuid.UUID
+	Mode    FilterMode
+	Pattern string
+}
+
+func (s *fsStore) ListFilters(chatID types.TgChatID) ([]Filter, error) {
+	var filters []Filter
+	if err := s.loadData(chatID, filtersFile, &filters); err != nil {
+		return nil, err
+	}
+	return filters, nil
+}
+
+func (s *fsStore) AddFilter(chatID types.TgChatID, filter Filter) error {
+	var filters []Filter
+	return s.updateData(chatID, filtersFile, &filters, func() error {
+		filters = append(filters, filter)
+		return nil
+	})
+}
+
+func (s *fsStore) DeleteFilter(chatID types.TgChatID, filterID uuid.UUID) error {
+	var filters []Filter
+	return s.updateData(chatID, filtersFile, &filters, func() error {
+		for i, f := range filters {
+			if f.ID == filterID {
+				filters = append(filters[:i], filters[i+1:]...)
+				return nil
+			}
+		}
+		return fmt.Errorf("cannot find filter: %w", ErrNotFound)
+	})
+}
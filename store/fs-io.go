@@ -3,6 +3,7 @@ package store
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"path"
 
@@ -11,6 +12,17 @@ import (
 
 var errSkipUpdate = errors.New("skip update")
 
+// ErrCorrupted is returned when the data file cannot be parsed, usually after
+// the crash of the previous version of the bot.
+var ErrCorrupted = errors.New("data file is corrupted")
+
+const (
+	// Suffix of the new file version being written
+	tmpSuffix = ".tmp"
+	// Suffix of the previous file version
+	backupSuffix = ".bak"
+)
+
 type optCfg struct {
 	MustExists bool
 	DeleteFile bool
@@ -47,7 +59,7 @@ func (s *fsStore) loadData(chatID tKey, baseName string, result interface{}, opt
 		try.Throw(err)
 	}
 
-	try.It(json.Unmarshal(data, result))
+	try.It(unmarshalFile(filePath, data, result))
 	if cfg.DeleteFile {
 		try.It(os.Remove(filePath))
 	}
@@ -67,7 +79,7 @@ func (s *fsStore) saveData(chatID tKey, baseName string, content interface{}) (e
 	try.It(os.MkdirAll(s.stateDirPath(chatID), dirsPerm))
 
 	filePath := path.Join(s.stateDirPath(chatID), baseName)
-	try.It(os.WriteFile(filePath, data, filesPerm))
+	try.It(writeFileAtomic(filePath, data))
 
 	return
 }
@@ -99,7 +111,7 @@ func (s *fsStore) updateData(chatID tKey, baseName string, result interface{}, p
 	}
 
 	if data != nil {
-		try.It(json.Unmarshal(data, result))
+		try.It(unmarshalFile(filePath, data, result))
 	}
 
 	if err = processor(); err == errSkipUpdate {
@@ -111,7 +123,73 @@ func (s *fsStore) updateData(chatID tKey, baseName string, result interface{}, p
 	data = try.ItVal(json.Marshal(result))
 
 	try.It(os.MkdirAll(s.stateDirPath(chatID), dirsPerm))
-	try.It(os.WriteFile(filePath, data, filesPerm))
+	try.It(writeFileAtomic(filePath, data))
 
 	return
 }
+
+func unmarshalFile(filePath string, data []byte, result interface{}) error {
+	if err := json.Unmarshal(data, result); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrCorrupted, filePath, err)
+	}
+	return nil
+}
+
+// writeFileAtomic writes the data to the temporary file and then replaces the
+// target file with it, so the target file is always either old or new, but
+// never partially written. The previous version of the file is kept as the
+// backup.
+func writeFileAtomic(filePath string, data []byte) (outErr error) {
+	defer try.HandleAs(&outErr)
+
+	tmpPath := filePath + tmpSuffix
+	f := try.ItVal(os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, filesPerm))
+	_, err := f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		try.Throw(err)
+	}
+
+	try.It(backupFile(filePath))
+
+	try.It(os.Rename(tmpPath, filePath))
+	syncDir(path.Dir(filePath))
+
+	return
+}
+
+// backupFile keeps the current version of the file as the backup. The backup is
+// the hard link to the current version, so it stays untouched after the file
+// is replaced. The copy is used if the filesystem doesn't support links.
+func backupFile(filePath string) error {
+	backupPath := filePath + backupSuffix
+	if err := os.Remove(backupPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	err := os.Link(filePath, backupPath)
+	if err == nil || errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(backupPath, data, filesPerm)
+}
+
+// syncDir makes the rename durable. Some platforms cannot sync directories, so
+// the errors are ignored.
+func syncDir(dirPath string) {
+	if d, err := os.Open(dirPath); err == nil {
+		d.Sync()
+		d.Close()
+	}
+}
@@ -0,0 +1,94 @@
+package store
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/FreeFeed/freefeed-tg-client/types"
+	"github.com/gofrs/uuid"
+)
+
+type MuteKind string
+
+const (
+	MutePost  MuteKind = "post"
+	MuteUser  MuteKind = "user"
+	MuteGroup MuteKind = "group"
+)
+
+// Mute hides the notifications about the post, or from the user, or from the
+// group.
+type Mute struct {
+	ID       uuid.UUID
+	Kind     MuteKind
+	TargetID uuid.UUID
+	// Title is the human-readable name of the target (username or post digest)
+	Title string
+	// Until is the mute expiration time, zero means forever
+	Until time.Time
+}
+
+func (m *Mute) IsExpired(now time.Time) bool {
+	return !m.Until.IsZero() && !now.Before(m.Until)
+}
+
+func (s *fsStore) ListMutes(chatID types.TgChatID) ([]Mute, error) {
+	var mutes []Mute
+	if err := s.loadData(chatID, mutesFile, &mutes); err != nil {
+		return nil, err
+	}
+	return mutes, nil
+}
+
+// AddMute adds the new mute or replaces the existing mute of the same target.
+func (s *fsStore) AddMute(chatID types.TgChatID, mute Mute) error {
+	var mutes []Mute
+	return s.updateData(chatID, mutesFile, &mutes, func() error {
+		for i, m := range mutes {
+			if m.Kind == mute.Kind && m.TargetID == mute.TargetID {
+				mute.ID = m.ID
+				mutes[i] = mute
+				return nil
+			}
+		}
+		mutes = append(mutes, mute)
+		return nil
+	})
+}
+
+func (s *fsStore) DeleteMute(chatID types.TgChatID, muteID uuid.UUID) error {
+	var mutes []Mute
+	return s.updateData(chatID, mutesFile, &mutes, func() error {
+		for i, m := range mutes {
+			if m.ID == muteID {
+				mutes = append(mutes[:i], mutes[i+1:]...)
+				return nil
+			}
+		}
+		return fmt.Errorf("cannot find mute: %w", ErrNotFound)
+	})
+}
+
+// DeleteExpiredMutes deletes the mutes expired at the given time and returns
+// the number of deleted mutes.
+func (s *fsStore) DeleteExpiredMutes(chatID types.TgChatID, now time.Time) (int, error) {
+	var (
+		mutes   []Mute
+		deleted int
+	)
+	err := s.updateData(chatID, mutesFile, &mutes, func() error {
+		active := mutes[:0]
+		for _, m := range mutes {
+			if !m.IsExpired(now) {
+				active = append(active, m)
+			}
+		}
+		deleted = len(mutes) - len(active)
+		if deleted == 0 {
+			return errSkipUpdate
+		}
+		mutes = active
+		return nil
+	})
+	return deleted, err
+}
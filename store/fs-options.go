@@ -1,7 +1,26 @@
 package store
 
+import "time"
+
 type FsOption func(s *fsStore)
 
+// FsMaxSentRecords limits the number of the sent message records of the chat,
+// regardless of their age. By default, the records are limited only by the
+// FsSentRecordsTTL.
 func FsMaxSentRecords(n int) FsOption {
 	return func(s *fsStore) { s.maxSentRecords = n }
 }
+
+// FsSentRecordsTTL sets the retention period of the sent message records. Zero
+// means no age limit.
+func FsSentRecordsTTL(d time.Duration) FsOption {
+	return func(s *fsStore) { s.sentRecordsTTL = d }
+}
+
+func FsMaxPostThreads(n int) FsOption {
+	return func(s *fsStore) { s.maxPostThreads = n }
+}
+
+func FsMaxSavedPosts(n int) FsOption {
+	return func(s *fsStore) { s.maxSavedPosts = n }
+}
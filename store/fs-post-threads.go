@@ -0,0 +1,47 @@
+package store
+
+import (
+	"fmt"
+
+	"github.com/FreeFeed/freefeed-tg-client/types"
+	"github.com/gofrs/uuid"
+)
+
+// PostThread binds the post to the first message sent about it. The later
+// messages about the same post are sent as replies to this message.
+type PostThread struct {
+	PostID    uuid.UUID
+	MessageID int
+}
+
+func (s *fsStore) GetPostThread(chatID types.TgChatID, postID uuid.UUID) (int, error) {
+	var threads []PostThread
+
+	if err := s.loadData(chatID, postThreadsFile, &threads); err != nil {
+		return 0, err
+	}
+
+	for _, thread := range threads {
+		if thread.PostID == postID {
+			return thread.MessageID, nil
+		}
+	}
+	return 0, fmt.Errorf("cannot find thread for this post: %w", ErrNotFound)
+}
+
+func (s *fsStore) PutPostThread(chatID types.TgChatID, postID uuid.UUID, messageID int) error {
+	var threads []PostThread
+	return s.updateData(chatID, postThreadsFile, &threads, func() error {
+		for i, thread := range threads {
+			if thread.PostID == postID {
+				threads[i].MessageID = messageID
+				return nil
+			}
+		}
+		threads = append(threads, PostThread{PostID: postID, MessageID: messageID})
+		if len(threads) > s.maxPostThreads {
+			threads = threads[len(threads)-s.maxPostThreads:]
+		}
+		return nil
+	})
+}
@@ -0,0 +1,68 @@
+package store
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/FreeFeed/freefeed-tg-client/types"
+	"github.com/gofrs/uuid"
+)
+
+// PostTopic is the forum topic created for the post in the topics mode.
+type PostTopic struct {
+	PostID   uuid.UUID
+	ThreadID int
+	// UpdatedAt is the time of the last message in the topic
+	UpdatedAt time.Time
+}
+
+func (s *fsStore) GetPostTopic(chatID types.TgChatID, postID uuid.UUID) (PostTopic, error) {
+	return s.findPostTopic(chatID, func(t PostTopic) bool { return t.PostID == postID })
+}
+
+func (s *fsStore) GetTopicByThread(chatID types.TgChatID, threadID int) (PostTopic, error) {
+	return s.findPostTopic(chatID, func(t PostTopic) bool { return t.ThreadID == threadID })
+}
+
+func (s *fsStore) findPostTopic(chatID types.TgChatID, pred func(PostTopic) bool) (PostTopic, error) {
+	topics, err := s.ListPostTopics(chatID)
+	if err != nil {
+		return PostTopic{}, err
+	}
+
+	if idx := slices.IndexFunc(topics, pred); idx >= 0 {
+		return topics[idx], nil
+	}
+	return PostTopic{}, fmt.Errorf("cannot find topic: %w", ErrNotFound)
+}
+
+func (s *fsStore) PutPostTopic(chatID types.TgChatID, topic PostTopic) error {
+	var topics []PostTopic
+	return s.updateData(chatID, postTopicsFile, &topics, func() error {
+		for i, t := range topics {
+			if t.PostID == topic.PostID {
+				topics[i] = topic
+				return nil
+			}
+		}
+		topics = append(topics, topic)
+		return nil
+	})
+}
+
+func (s *fsStore) DeletePostTopic(chatID types.TgChatID, postID uuid.UUID) error {
+	var topics []PostTopic
+	return s.updateData(chatID, postTopicsFile, &topics, func() error {
+		topics = slices.DeleteFunc(topics, func(t PostTopic) bool { return t.PostID == postID })
+		return nil
+	})
+}
+
+func (s *fsStore) ListPostTopics(chatID types.TgChatID) ([]PostTopic, error) {
+	var topics []PostTopic
+	if err := s.loadData(chatID, postTopicsFile, &topics); err != nil {
+		return nil, err
+	}
+	return topics, nil
+}
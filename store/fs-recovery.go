@@ -0,0 +1,76 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/FreeFeed/freefeed-tg-client/types"
+	"github.com/davidmz/go-try"
+)
+
+// quarantineDir is the directory (inside the data directory) for the corrupted
+// files. It is not a chat directory, so ListIDs ignores it.
+const quarantineDir = "quarantine"
+
+// QuarantineCorrupted checks the data files of the chat and moves the
+// corrupted ones to the quarantine directory. The corrupted file is restored
+// from the backup when possible. It returns the names of the quarantined
+// files.
+func (s *fsStore) QuarantineCorrupted(chatID types.TgChatID) (_ []string, outErr error) {
+	defer try.HandleAs(&outErr)
+
+	lk, release := s.fileLock(chatID)
+	defer release()
+	lk.Lock()
+	defer lk.Unlock()
+
+	dirPath := s.stateDirPath(chatID)
+	entries, err := os.ReadDir(dirPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	try.It(err)
+
+	var quarantined []string
+	for _, ent := range entries {
+		name := ent.Name()
+		filePath := path.Join(dirPath, name)
+
+		if strings.HasSuffix(name, tmpSuffix) {
+			// The leftover of the interrupted write, the target file is intact
+			try.It(os.Remove(filePath))
+			continue
+		}
+		if ent.IsDir() || path.Ext(name) != ".json" || isValidJSONFile(filePath) {
+			continue
+		}
+
+		qPath := path.Join(s.dirName, quarantineDir, strconv.FormatInt(chatID, 10))
+		try.It(os.MkdirAll(qPath, dirsPerm))
+		qName := name + "." + time.Now().UTC().Format("20060102T150405")
+		try.It(os.Rename(filePath, path.Join(qPath, qName)))
+		quarantined = append(quarantined, name)
+
+		backupPath := filePath + backupSuffix
+		if isValidJSONFile(backupPath) {
+			try.It(os.Rename(backupPath, filePath))
+		}
+	}
+
+	if len(quarantined) > 0 {
+		// The sent records index may be built from the quarantined file
+		s.dropMsgIndex(chatID)
+	}
+
+	return quarantined, nil
+}
+
+func isValidJSONFile(filePath string) bool {
+	data, err := os.ReadFile(filePath)
+	return err == nil && json.Valid(data)
+}
@@ -0,0 +1,50 @@
+package store
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/FreeFeed/freefeed-tg-client/frf"
+	"github.com/FreeFeed/freefeed-tg-client/types"
+	"github.com/gofrs/uuid"
+)
+
+// Reminder is the event that should be sent to user again at the given time.
+type Reminder struct {
+	ID     uuid.UUID
+	FireAt time.Time
+	Event  *frf.Event
+	// MessageID is the ID of the message with the "Cancel" button
+	MessageID int
+}
+
+func (s *fsStore) ListReminders(chatID types.TgChatID) ([]Reminder, error) {
+	var reminders []Reminder
+	if err := s.loadData(chatID, remindersFile, &reminders); err != nil {
+		return nil, err
+	}
+	return reminders, nil
+}
+
+func (s *fsStore) AddReminder(chatID types.TgChatID, reminder Reminder) error {
+	var reminders []Reminder
+	return s.updateData(chatID, remindersFile, &reminders, func() error {
+		reminders = append(reminders, reminder)
+		return nil
+	})
+}
+
+// DeleteReminder deletes the reminder. It returns ErrNotFound if the reminder
+// is already deleted (fired or cancelled).
+func (s *fsStore) DeleteReminder(chatID types.TgChatID, reminderID uuid.UUID) error {
+	var reminders []Reminder
+	return s.updateData(chatID, remindersFile, &reminders, func() error {
+		for i, r := range reminders {
+			if r.ID == reminderID {
+				reminders = append(reminders[:i], reminders[i+1:]...)
+				return nil
+			}
+		}
+		return fmt.Errorf("cannot find reminder: %w", ErrNotFound)
+	})
+}
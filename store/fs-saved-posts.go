@@ -0,0 +1,73 @@
+package store
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/FreeFeed/freefeed-tg-client/types"
+	"github.com/gofrs/uuid"
+)
+
+// SavedPost is the post bookmarked by user.
+type SavedPost struct {
+	PostID  uuid.UUID
+	Digest  string
+	SavedAt time.Time
+}
+
+// ListSavedPosts returns the saved posts, the most recently saved first.
+func (s *fsStore) ListSavedPosts(chatID types.TgChatID) ([]SavedPost, error) {
+	var posts []SavedPost
+	if err := s.loadData(chatID, savedPostsFile, &posts); err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(posts)-1; i < j; i, j = i+1, j-1 {
+		posts[i], posts[j] = posts[j], posts[i]
+	}
+	return posts, nil
+}
+
+// SavePost saves the post. The oldest posts are removed when the number of
+// saved posts exceeds the FsMaxSavedPosts. When the sync with the server is
+// enabled, the removed posts are still listed from the server.
+func (s *fsStore) SavePost(chatID types.TgChatID, post SavedPost) error {
+	var posts []SavedPost
+	return s.updateData(chatID, savedPostsFile, &posts, func() error {
+		for _, p := range posts {
+			if p.PostID == post.PostID {
+				return errSkipUpdate
+			}
+		}
+		posts = append(posts, post)
+		if len(posts) > s.maxSavedPosts {
+			posts = posts[len(posts)-s.maxSavedPosts:]
+		}
+		return nil
+	})
+}
+
+func (s *fsStore) UnsavePost(chatID types.TgChatID, postID uuid.UUID) error {
+	var posts []SavedPost
+	return s.updateData(chatID, savedPostsFile, &posts, func() error {
+		for i, p := range posts {
+			if p.PostID == postID {
+				posts = append(posts[:i], posts[i+1:]...)
+				return nil
+			}
+		}
+		return fmt.Errorf("cannot find saved post: %w", ErrNotFound)
+	})
+}
+
+func (s *fsStore) IsPostSaved(chatID types.TgChatID, postID uuid.UUID) (bool, error) {
+	var posts []SavedPost
+	if err := s.loadData(chatID, savedPostsFile, &posts); err != nil {
+		return false, err
+	}
+	for _, p := range posts {
+		if p.PostID == postID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
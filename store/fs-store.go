@@ -7,6 +7,7 @@ import (
 	"path"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/FreeFeed/freefeed-tg-client/types"
 )
@@ -16,11 +17,16 @@ type tKey = types.TgChatID
 // NewFsStore creates a new file-based Store.
 func NewFsStore(dirName string, options ...FsOption) Store {
 	s := &fsStore{
-		dirName:   dirName,
-		fileLocks: make(map[tKey]*sync.RWMutex),
+		dirName:    dirName,
+		fileLocks:  make(map[tKey]*sync.RWMutex),
+		msgIndexes: make(map[tKey]*msgIndex),
 	}
 
-	options = append([]FsOption{FsMaxSentRecords(1000)}, options...)
+	options = append([]FsOption{
+		FsSentRecordsTTL(90 * 24 * time.Hour),
+		FsMaxPostThreads(1000),
+		FsMaxSavedPosts(500),
+	}, options...)
 	for _, option := range options {
 		option(s)
 	}
@@ -32,10 +38,18 @@ const (
 	dirsPerm  = 0775
 	filesPerm = 0600
 
-	stateFile        = "state.json"
-	queueFile        = "queue.json"
-	sentEventsFile   = "sent-events.json"
-	trackedPostsFile = "tracked-posts.json"
+	stateFile         = "state.json"
+	queueFile         = "queue.json"
+	sentEventsFile    = "sent-events.json" // legacy, see migrateMsgRecs
+	sentEventsLogFile = "sent-events.log"
+	trackedPostsFile  = "tracked-posts.json"
+	postThreadsFile   = "post-threads.json"
+	postTopicsFile    = "post-topics.json"
+	watchesFile       = "watches.json"
+	mutesFile         = "mutes.json"
+	filtersFile       = "filters.json"
+	remindersFile     = "reminders.json"
+	savedPostsFile    = "saved-posts.json"
 )
 
 type fsStore struct {
@@ -43,7 +57,13 @@ type fsStore struct {
 	fileLocks map[tKey]*sync.RWMutex
 	dirName   string
 
+	msgIndexesLock sync.Mutex
+	msgIndexes     map[tKey]*msgIndex
+
 	maxSentRecords int
+	sentRecordsTTL time.Duration
+	maxPostThreads int
+	maxSavedPosts  int
 }
 
 func (s *fsStore) fileLock(key tKey) (*sync.RWMutex, func()) {
@@ -89,6 +109,7 @@ func (s *fsStore) DeleteState(chatID tKey) error {
 	}
 
 	delete(s.fileLocks, chatID)
+	s.dropMsgIndex(chatID)
 	return nil
 }
 
@@ -8,7 +8,26 @@ import (
 )
 
 type TrackedEntities struct {
+	// Legacy tracked posts, subscribed in realtime
 	PostIDs []uuid.UUID
+	// Posts with the NotifyOfAllComments flag set through the bot
+	CommentPostIDs []uuid.UUID
+	// Feeds followed in realtime
+	Feeds []TrackedFeed
+}
+
+// TrackedFeed is the timeline of user or group followed in realtime
+type TrackedFeed struct {
+	ID       uuid.UUID
+	UserName string
+}
+
+func (t TrackedEntities) FeedIDs() []uuid.UUID {
+	ids := make([]uuid.UUID, len(t.Feeds))
+	for i, f := range t.Feeds {
+		ids[i] = f.ID
+	}
+	return ids
 }
 
 func (s *fsStore) TrackedEntities(chatID types.TgChatID) (TrackedEntities, error) {
@@ -43,8 +62,59 @@ func (s *fsStore) UntrackPost(chatID types.TgChatID, postID uuid.UUID) error {
 		&tracked,
 		func() error {
 			if idx := slices.Index(tracked.PostIDs, postID); idx >= 0 {
-				slices.Delete(tracked.PostIDs, idx, idx+1)
+				tracked.PostIDs = slices.Delete(tracked.PostIDs, idx, idx+1)
+			}
+			return nil
+		},
+	)
+}
+
+// SetPostCommentsNotify remembers that the NotifyOfAllComments flag of the post
+// is set or unset through the bot.
+func (s *fsStore) SetPostCommentsNotify(chatID types.TgChatID, postID uuid.UUID, enabled bool) error {
+	var tracked TrackedEntities
+	return s.updateData(
+		chatID,
+		trackedPostsFile,
+		&tracked,
+		func() error {
+			idx := slices.Index(tracked.CommentPostIDs, postID)
+			if enabled && idx < 0 {
+				tracked.CommentPostIDs = append(tracked.CommentPostIDs, postID)
+			} else if !enabled && idx >= 0 {
+				tracked.CommentPostIDs = slices.Delete(tracked.CommentPostIDs, idx, idx+1)
+			} else {
+				return errSkipUpdate
+			}
+			return nil
+		},
+	)
+}
+
+func (s *fsStore) TrackFeed(chatID types.TgChatID, feed TrackedFeed) error {
+	var tracked TrackedEntities
+	return s.updateData(
+		chatID,
+		trackedPostsFile,
+		&tracked,
+		func() error {
+			if slices.ContainsFunc(tracked.Feeds, func(f TrackedFeed) bool { return f.ID == feed.ID }) {
+				return errSkipUpdate
 			}
+			tracked.Feeds = append(tracked.Feeds, feed)
+			return nil
+		},
+	)
+}
+
+func (s *fsStore) UntrackFeed(chatID types.TgChatID, feedID uuid.UUID) error {
+	var tracked TrackedEntities
+	return s.updateData(
+		chatID,
+		trackedPostsFile,
+		&tracked,
+		func() error {
+			tracked.Feeds = slices.DeleteFunc(tracked.Feeds, func(f TrackedFeed) bool { return f.ID == feedID })
 			return nil
 		},
 	)
@@ -0,0 +1,64 @@
+package store
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/FreeFeed/freefeed-tg-client/types"
+	"github.com/gofrs/uuid"
+)
+
+// Watch is the saved search query. The bot periodically runs it and notifies
+// the user about the new posts.
+type Watch struct {
+	ID    uuid.UUID
+	Query string
+	// LastPostAt is the creation time of the newest post seen in the search
+	// results
+	LastPostAt time.Time
+	NextPollAt time.Time
+}
+
+func (s *fsStore) ListWatches(chatID types.TgChatID) ([]Watch, error) {
+	var watches []Watch
+	if err := s.loadData(chatID, watchesFile, &watches); err != nil {
+		return nil, err
+	}
+	return watches, nil
+}
+
+func (s *fsStore) AddWatch(chatID types.TgChatID, watch Watch) error {
+	var watches []Watch
+	return s.updateData(chatID, watchesFile, &watches, func() error {
+		watches = append(watches, watch)
+		return nil
+	})
+}
+
+// UpdateWatch updates the existing watch with the same ID. It returns
+// ErrNotFound if the watch was deleted.
+func (s *fsStore) UpdateWatch(chatID types.TgChatID, watch Watch) error {
+	var watches []Watch
+	return s.updateData(chatID, watchesFile, &watches, func() error {
+		for i, w := range watches {
+			if w.ID == watch.ID {
+				watches[i] = watch
+				return nil
+			}
+		}
+		return fmt.Errorf("cannot find watch: %w", ErrNotFound)
+	})
+}
+
+func (s *fsStore) DeleteWatch(chatID types.TgChatID, watchID uuid.UUID) error {
+	var watches []Watch
+	return s.updateData(chatID, watchesFile, &watches, func() error {
+		for i, w := range watches {
+			if w.ID == watchID {
+				watches = append(watches[:i], watches[i+1:]...)
+				return nil
+			}
+		}
+		return fmt.Errorf("cannot find watch: %w", ErrNotFound)
+	})
+}
@@ -3,6 +3,7 @@ package store
 import (
 	"encoding/json"
 	"errors"
+	"time"
 
 	"github.com/FreeFeed/freefeed-tg-client/types"
 	"github.com/gofrs/uuid"
@@ -15,6 +16,9 @@ type Store interface {
 	SaveState(state *State) error
 	DeleteState(chatID types.TgChatID) error
 	ListIDs() ([]types.TgChatID, error)
+	// QuarantineCorrupted moves the corrupted data files of the chat away and
+	// returns their names
+	QuarantineCorrupted(chatID types.TgChatID) ([]string, error)
 
 	// EventsQueue
 	AddToQueue(chatID types.TgChatID, entry json.RawMessage) error
@@ -23,12 +27,56 @@ type Store interface {
 	// EventsStore
 	GetMsgRec(chatID types.TgChatID, messageID int) (SentMsgRec, error)
 	PutMsgRec(chatID types.TgChatID, rec SentMsgRec) error
+	ListPostMsgRecs(chatID types.TgChatID, postID uuid.UUID) ([]SentMsgRec, error)
+	CompactMsgRecs(chatID types.TgChatID, now time.Time) (int, error)
+
+	// Post threads
+	GetPostThread(chatID types.TgChatID, postID uuid.UUID) (int, error)
+	PutPostThread(chatID types.TgChatID, postID uuid.UUID, messageID int) error
+
+	// Forum topics
+	GetPostTopic(chatID types.TgChatID, postID uuid.UUID) (PostTopic, error)
+	GetTopicByThread(chatID types.TgChatID, threadID int) (PostTopic, error)
+	PutPostTopic(chatID types.TgChatID, topic PostTopic) error
+	DeletePostTopic(chatID types.TgChatID, postID uuid.UUID) error
+	ListPostTopics(chatID types.TgChatID) ([]PostTopic, error)
+
+	// Saved searches
+	ListWatches(chatID types.TgChatID) ([]Watch, error)
+	AddWatch(chatID types.TgChatID, watch Watch) error
+	UpdateWatch(chatID types.TgChatID, watch Watch) error
+	DeleteWatch(chatID types.TgChatID, watchID uuid.UUID) error
+
+	// Mutes
+	ListMutes(chatID types.TgChatID) ([]Mute, error)
+	AddMute(chatID types.TgChatID, mute Mute) error
+	DeleteMute(chatID types.TgChatID, muteID uuid.UUID) error
+	DeleteExpiredMutes(chatID types.TgChatID, now time.Time) (int, error)
+
+	// Content filters
+	ListFilters(chatID types.TgChatID) ([]Filter, error)
+	AddFilter(chatID types.TgChatID, filter Filter) error
+	DeleteFilter(chatID types.TgChatID, filterID uuid.UUID) error
+
+	// Reminders
+	ListReminders(chatID types.TgChatID) ([]Reminder, error)
+	AddReminder(chatID types.TgChatID, reminder Reminder) error
+	DeleteReminder(chatID types.TgChatID, reminderID uuid.UUID) error
+
+	// Bookmarks
+	ListSavedPosts(chatID types.TgChatID) ([]SavedPost, error)
+	SavePost(chatID types.TgChatID, post SavedPost) error
+	UnsavePost(chatID types.TgChatID, postID uuid.UUID) error
+	IsPostSaved(chatID types.TgChatID, postID uuid.UUID) (bool, error)
 
 	// Tracked posts
 	TrackPost(chatID types.TgChatID, postID uuid.UUID) error
 	UntrackPost(chatID types.TgChatID, postID uuid.UUID) error
 	IsPostTracked(chatID types.TgChatID, postID uuid.UUID) (bool, error)
 	TrackedEntities(chatID types.TgChatID) (TrackedEntities, error)
+	SetPostCommentsNotify(chatID types.TgChatID, postID uuid.UUID, enabled bool) error
+	TrackFeed(chatID types.TgChatID, feed TrackedFeed) error
+	UntrackFeed(chatID types.TgChatID, feedID uuid.UUID) error
 }
 
 func NewChatState(chatID types.TgChatID) *State {
@@ -1,6 +1,8 @@
 package store
 
 import (
+	"time"
+
 	"github.com/FreeFeed/freefeed-tg-client/types"
 	"github.com/gofrs/uuid"
 	"golang.org/x/text/language"
@@ -13,6 +15,15 @@ const (
 	ExpectLanguage  Expectation = "lang"
 	ExpectAuthToken Expectation = "token"
 	ExpectComment   Expectation = "comment"
+	// ExpectDirectChat means that every message is a comment to the direct
+	// post. It lasts until the /endchat command.
+	ExpectDirectChat Expectation = "directChat"
+	// Creation of the new direct message
+	ExpectDirectRecipients Expectation = "directRecipients"
+	ExpectDirectText       Expectation = "directText"
+	// ExpectReminderTime means that user chooses the custom reminder time for
+	// the ReactToMessageID message
+	ExpectReminderTime Expectation = "reminderTime"
 )
 
 // State is the saved state of a chat.
@@ -27,6 +38,42 @@ type State struct {
 	ReactToMessageID int
 	CommentToPostID  uuid.UUID
 	CommentPrefix    string
+
+	// DeactivatedAt is the time when the bot found that the chat is
+	// unreachable (e.g. user blocked the bot). Zero value means the chat is
+	// active.
+	DeactivatedAt time.Time
+
+	// TokenRevoked is true if FreeFeed has rejected the AccessToken. The bot
+	// does nothing but waits for a new token in this state.
+	TokenRevoked bool
+	// Metadata of the AccessToken
+	TokenExpiresAt    time.Time
+	TokenScopes       []string
+	TokenExpiryWarned bool
+
+	// TopicsMode is true if the chat is a forum supergroup where the bot
+	// creates a separate topic for every post.
+	TopicsMode bool
+	// TopicsOwnerID is the Telegram ID of the user who enabled the topics mode.
+	// Only messages of this user are posted to FreeFeed as comments.
+	TopicsOwnerID int64
+
+	// The direct post and the interlocutor of the conversation mode
+	DirectChatPostID uuid.UUID
+	DirectChatUser   string
+
+	// Recipients of the new direct message
+	DirectRecipients []string
+
+	// The last search query, used for the search results pagination
+	SearchQuery string
+	// SearchSeq is incremented on every new search, to detect the "Next page"
+	// buttons of the previous searches
+	SearchSeq int
+	// MigrationVersion is the version of the chat data format, see the
+	// app.migrateChat
+	MigrationVersion int
 }
 
 // IsAuthorized returns true if the user is authorized.
@@ -34,11 +81,42 @@ func (s *State) IsAuthorized() bool {
 	return s.AccessToken != ""
 }
 
+// IsActive returns true if the bot can send messages to the chat.
+func (s *State) IsActive() bool {
+	return s.DeactivatedAt.IsZero()
+}
+
+func (s *State) Deactivate() {
+	s.DeactivatedAt = time.Now()
+}
+
+func (s *State) Activate() {
+	s.DeactivatedAt = time.Time{}
+}
+
 func (s *State) ClearExpectations() {
 	s.Expectation = ""
 	s.ReactToMessageID = 0
 	s.CommentToPostID = uuid.Nil
 	s.CommentPrefix = ""
+	s.DirectRecipients = nil
+	if s.DirectChatPostID != uuid.Nil {
+		// The conversation mode survives the other expectations
+		s.Expectation = ExpectDirectChat
+	}
+}
+
+func (s *State) StartDirectChat(postID uuid.UUID, userName string) {
+	s.ClearExpectations()
+	s.DirectChatPostID = postID
+	s.DirectChatUser = userName
+	s.Expectation = ExpectDirectChat
+}
+
+func (s *State) EndDirectChat() {
+	s.DirectChatPostID = uuid.Nil
+	s.DirectChatUser = ""
+	s.ClearExpectations()
 }
 
 func (s *State) IsPausedExpectation() bool {
@@ -1,10 +1,15 @@
 package store_test
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"os"
+	"path"
 	"testing"
+	"time"
 
+	"github.com/FreeFeed/freefeed-tg-client/frf"
 	"github.com/FreeFeed/freefeed-tg-client/store"
 	"github.com/FreeFeed/freefeed-tg-client/types"
 	"github.com/gofrs/uuid"
@@ -70,6 +75,83 @@ func (s *StoreTestSite) TestDeleteState() {
 	s.Nil(state1)
 }
 
+// Crash safety
+
+func (s *StoreTestSite) TestStateBackup() {
+	state := &store.State{ID: 123, ReactToMessageID: 321}
+	s.NoError(s.store.SaveState(state))
+	state2 := &store.State{ID: 123, ReactToMessageID: 322}
+	s.NoError(s.store.SaveState(state2))
+
+	data, err := os.ReadFile(path.Join(s.dir, "123", "state.json.bak"))
+	s.NoError(err)
+	backup := new(store.State)
+	s.NoError(json.Unmarshal(data, backup))
+	s.Equal(state, backup)
+
+	_, err = os.Stat(path.Join(s.dir, "123", "state.json.tmp"))
+	s.ErrorIs(err, os.ErrNotExist)
+}
+
+func (s *StoreTestSite) TestCorruptedState() {
+	state := &store.State{ID: 123, ReactToMessageID: 321}
+	s.NoError(s.store.SaveState(state))
+	s.NoError(s.store.SaveState(&store.State{ID: 123, ReactToMessageID: 322}))
+
+	// Simulate the partial write of the old non-atomic version
+	statePath := path.Join(s.dir, "123", "state.json")
+	s.NoError(os.WriteFile(statePath, []byte(`{"ID":123,"React`), 0600))
+
+	_, err := s.store.LoadState(123)
+	s.ErrorIs(err, store.ErrCorrupted)
+
+	quarantined, err := s.store.QuarantineCorrupted(123)
+	s.NoError(err)
+	s.Equal([]string{"state.json"}, quarantined)
+
+	// Restored from the backup
+	state1, err := s.store.LoadState(123)
+	s.NoError(err)
+	s.Equal(state, state1)
+
+	entries, err := os.ReadDir(path.Join(s.dir, "quarantine", "123"))
+	s.NoError(err)
+	s.Len(entries, 1)
+
+	list, err := s.store.ListIDs()
+	s.NoError(err)
+	s.Equal([]types.TgChatID{123}, list)
+}
+
+func (s *StoreTestSite) TestCorruptedWithoutBackup() {
+	const chatID = 123
+	s.NoError(s.store.SaveState(&store.State{ID: chatID}))
+	s.NoError(os.WriteFile(path.Join(s.dir, "123", "mutes.json"), []byte(`[{"ID":`), 0600))
+	// The leftover of the interrupted atomic write
+	s.NoError(os.WriteFile(path.Join(s.dir, "123", "state.json.tmp"), []byte(`{"ID":1`), 0600))
+
+	_, err := s.store.ListMutes(chatID)
+	s.ErrorIs(err, store.ErrCorrupted)
+
+	quarantined, err := s.store.QuarantineCorrupted(chatID)
+	s.NoError(err)
+	s.Equal([]string{"mutes.json"}, quarantined)
+
+	mutes, err := s.store.ListMutes(chatID)
+	s.NoError(err)
+	s.Empty(mutes)
+
+	_, err = s.store.LoadState(chatID)
+	s.NoError(err)
+	_, err = os.Stat(path.Join(s.dir, "123", "state.json.tmp"))
+	s.ErrorIs(err, os.ErrNotExist)
+
+	// Nothing to do the second time
+	quarantined, err = s.store.QuarantineCorrupted(chatID)
+	s.NoError(err)
+	s.Empty(quarantined)
+}
+
 // List
 
 func (s *StoreTestSite) TestListIDs() {
@@ -145,7 +227,7 @@ func (s *StoreTestSite) TestEmptySentMsgRecs() {
 
 func (s *StoreTestSite) TestSentMsgRecs() {
 	const chatID = 123
-	recs := []store.SentMsgRec{{MessageID: 1234}, {MessageID: 1235}, {MessageID: 1236}}
+	recs := []store.SentMsgRec{{MessageID: 1234}, {MessageID: 1235, Truncated: true, Rest: []string{"tail"}}, {MessageID: 1236}}
 
 	for _, rec := range recs {
 		err := s.store.PutMsgRec(chatID, rec)
@@ -159,6 +241,22 @@ func (s *StoreTestSite) TestSentMsgRecs() {
 	}
 }
 
+func (s *StoreTestSite) TestReplaceSentMsgRec() {
+	const chatID = 123
+	recs := []store.SentMsgRec{{MessageID: 1234}, {MessageID: 1235, Truncated: true}}
+	for _, rec := range recs {
+		err := s.store.PutMsgRec(chatID, rec)
+		s.NoError(err)
+	}
+
+	err := s.store.PutMsgRec(chatID, store.SentMsgRec{MessageID: 1235})
+	s.NoError(err)
+
+	rec, err := s.store.GetMsgRec(chatID, 1235)
+	s.NoError(err)
+	s.False(rec.Truncated)
+}
+
 func (s *StoreTestSite) TestMaxSentMsgRecs() {
 	const chatID = 123
 	recs := []store.SentMsgRec{
@@ -187,6 +285,330 @@ func (s *StoreTestSite) TestMaxSentMsgRecs() {
 	}
 }
 
+func (s *StoreTestSite) TestPostSentMsgRecs() {
+	const chatID = 123
+	postID, _ := uuid.NewV4()
+	postID2, _ := uuid.NewV4()
+	recs := []store.SentMsgRec{
+		{MessageID: 1236, Event: &frf.Event{PostID: postID}},
+		{MessageID: 1234, Event: &frf.Event{PostID: postID}},
+		{MessageID: 1235, Event: &frf.Event{PostID: postID2}},
+		{MessageID: 1237},
+	}
+	for _, rec := range recs {
+		s.NoError(s.store.PutMsgRec(chatID, rec))
+	}
+
+	list, err := s.store.ListPostMsgRecs(chatID, postID)
+	s.NoError(err)
+	s.Equal([]store.SentMsgRec{recs[1], recs[0]}, list)
+
+	// The replaced record moves to the other post
+	s.NoError(s.store.PutMsgRec(chatID, store.SentMsgRec{MessageID: 1236, Event: &frf.Event{PostID: postID2}}))
+	list, err = s.store.ListPostMsgRecs(chatID, postID)
+	s.NoError(err)
+	s.Equal([]store.SentMsgRec{recs[1]}, list)
+	list, err = s.store.ListPostMsgRecs(chatID, postID2)
+	s.NoError(err)
+	s.Len(list, 2)
+}
+
+func (s *StoreTestSite) TestSentMsgRecsNoCountLimitByDefault() {
+	const chatID = 123
+	st := store.NewFsStore(s.dir)
+	for i := 0; i < maxSentRecords+3; i++ {
+		s.NoError(st.PutMsgRec(chatID, store.SentMsgRec{MessageID: 1000 + i}))
+	}
+
+	_, err := st.GetMsgRec(chatID, 1000)
+	s.NoError(err)
+}
+
+func (s *StoreTestSite) TestSentMsgRecsTTL() {
+	const chatID = 123
+	st := store.NewFsStore(s.dir, store.FsSentRecordsTTL(time.Hour))
+	recs := []store.SentMsgRec{{MessageID: 1234}, {MessageID: 1235}}
+	for _, rec := range recs {
+		s.NoError(st.PutMsgRec(chatID, rec))
+	}
+
+	removed, err := st.CompactMsgRecs(chatID, time.Now())
+	s.NoError(err)
+	s.Equal(0, removed)
+
+	removed, err = st.CompactMsgRecs(chatID, time.Now().Add(2*time.Hour))
+	s.NoError(err)
+	s.Equal(2, removed)
+
+	_, err = st.GetMsgRec(chatID, 1234)
+	s.ErrorIs(err, store.ErrNotFound)
+	_, err = os.Stat(path.Join(s.dir, "123", "sent-events.log"))
+	s.ErrorIs(err, os.ErrNotExist)
+}
+
+func (s *StoreTestSite) TestSentMsgRecsCompaction() {
+	const chatID = 123
+	for i := 0; i < maxSentRecords+3; i++ {
+		s.NoError(s.store.PutMsgRec(chatID, store.SentMsgRec{MessageID: 1234 + i}))
+	}
+	s.NoError(s.store.PutMsgRec(chatID, store.SentMsgRec{MessageID: 1237, Truncated: true}))
+
+	removed, err := s.store.CompactMsgRecs(chatID, time.Now())
+	s.NoError(err)
+	s.Equal(0, removed)
+
+	data, err := os.ReadFile(path.Join(s.dir, "123", "sent-events.log"))
+	s.NoError(err)
+	s.Equal(maxSentRecords, bytes.Count(data, []byte("\n")))
+
+	// Records survive the store restart
+	st := store.NewFsStore(s.dir, store.FsMaxSentRecords(maxSentRecords))
+	rec, err := st.GetMsgRec(chatID, 1237)
+	s.NoError(err)
+	s.True(rec.Truncated)
+	_, err = st.GetMsgRec(chatID, 1236)
+	s.ErrorIs(err, store.ErrNotFound)
+}
+
+func (s *StoreTestSite) TestSentMsgRecsBrokenTail() {
+	const chatID = 123
+	s.NoError(s.store.PutMsgRec(chatID, store.SentMsgRec{MessageID: 1234}))
+
+	// Simulate the interrupted write
+	logPath := path.Join(s.dir, "123", "sent-events.log")
+	f, err := os.OpenFile(logPath, os.O_APPEND|os.O_WRONLY, 0)
+	s.NoError(err)
+	_, err = f.WriteString(`{"SentAt":"2024-01-01T00:00:00Z","Rec`)
+	s.NoError(err)
+	s.NoError(f.Close())
+
+	st := store.NewFsStore(s.dir)
+	s.NoError(st.PutMsgRec(chatID, store.SentMsgRec{MessageID: 1235}))
+	for _, messageID := range []int{1234, 1235} {
+		rec, err := st.GetMsgRec(chatID, messageID)
+		s.NoError(err)
+		s.Equal(messageID, rec.MessageID)
+	}
+}
+
+func (s *StoreTestSite) TestLegacySentMsgRecs() {
+	const chatID = 123
+	recs := []store.SentMsgRec{{MessageID: 1234}, {MessageID: 1235, Truncated: true}}
+	data, err := json.Marshal(recs)
+	s.NoError(err)
+	s.NoError(os.MkdirAll(path.Join(s.dir, "123"), 0775))
+	s.NoError(os.WriteFile(path.Join(s.dir, "123", "sent-events.json"), data, 0600))
+
+	for _, rec := range recs {
+		rec1, err := s.store.GetMsgRec(chatID, rec.MessageID)
+		s.NoError(err)
+		s.Equal(rec, rec1)
+	}
+	_, err = os.Stat(path.Join(s.dir, "123", "sent-events.json"))
+	s.ErrorIs(err, os.ErrNotExist)
+}
+
+// Post threads
+
+func (s *StoreTestSite) TestPostThreads() {
+	const chatID = 123
+	postID, _ := uuid.NewV4()
+
+	_, err := s.store.GetPostThread(chatID, postID)
+	s.ErrorIs(err, store.ErrNotFound)
+
+	err = s.store.PutPostThread(chatID, postID, 1234)
+	s.NoError(err)
+
+	// Post thread should survive the sent records trimming
+	for i := 0; i < maxSentRecords+1; i++ {
+		err := s.store.PutMsgRec(chatID, store.SentMsgRec{MessageID: 1235 + i})
+		s.NoError(err)
+	}
+
+	messageID, err := s.store.GetPostThread(chatID, postID)
+	s.NoError(err)
+	s.Equal(1234, messageID)
+}
+
+// Forum topics
+
+func (s *StoreTestSite) TestPostTopics() {
+	const chatID = -123
+	postID, _ := uuid.NewV4()
+	postID2, _ := uuid.NewV4()
+
+	_, err := s.store.GetPostTopic(chatID, postID)
+	s.ErrorIs(err, store.ErrNotFound)
+
+	topic := store.PostTopic{PostID: postID, ThreadID: 10, UpdatedAt: time.Now().Round(0)}
+	err = s.store.PutPostTopic(chatID, topic)
+	s.NoError(err)
+	err = s.store.PutPostTopic(chatID, store.PostTopic{PostID: postID2, ThreadID: 20})
+	s.NoError(err)
+
+	topic1, err := s.store.GetPostTopic(chatID, postID)
+	s.NoError(err)
+	s.True(topic.UpdatedAt.Equal(topic1.UpdatedAt))
+	s.Equal(topic.ThreadID, topic1.ThreadID)
+
+	topic2, err := s.store.GetTopicByThread(chatID, 20)
+	s.NoError(err)
+	s.Equal(postID2, topic2.PostID)
+
+	err = s.store.DeletePostTopic(chatID, postID)
+	s.NoError(err)
+
+	topics, err := s.store.ListPostTopics(chatID)
+	s.NoError(err)
+	s.Len(topics, 1)
+	s.Equal(postID2, topics[0].PostID)
+}
+
+// Saved searches
+
+func (s *StoreTestSite) TestWatches() {
+	const chatID = 123
+	watch := store.Watch{ID: uuid.Must(uuid.NewV4()), Query: "from:alice"}
+	watch2 := store.Watch{ID: uuid.Must(uuid.NewV4()), Query: "cats"}
+
+	s.NoError(s.store.AddWatch(chatID, watch))
+	s.NoError(s.store.AddWatch(chatID, watch2))
+
+	watch.LastPostAt = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	s.NoError(s.store.UpdateWatch(chatID, watch))
+
+	watches, err := s.store.ListWatches(chatID)
+	s.NoError(err)
+	s.Equal([]store.Watch{watch, watch2}, watches)
+
+	s.NoError(s.store.DeleteWatch(chatID, watch.ID))
+	s.ErrorIs(s.store.DeleteWatch(chatID, watch.ID), store.ErrNotFound)
+	s.ErrorIs(s.store.UpdateWatch(chatID, watch), store.ErrNotFound)
+
+	watches, err = s.store.ListWatches(chatID)
+	s.NoError(err)
+	s.Equal([]store.Watch{watch2}, watches)
+}
+
+func (s *StoreTestSite) TestMutes() {
+	const chatID = 123
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	userID := uuid.Must(uuid.NewV4())
+	postMute := store.Mute{ID: uuid.Must(uuid.NewV4()), Kind: store.MutePost, TargetID: uuid.Must(uuid.NewV4())}
+	userMute := store.Mute{ID: uuid.Must(uuid.NewV4()), Kind: store.MuteUser, TargetID: userID, Until: now.Add(time.Hour)}
+
+	s.NoError(s.store.AddMute(chatID, postMute))
+	s.NoError(s.store.AddMute(chatID, userMute))
+
+	// Mute of the same target replaces the existing one
+	userMute2 := store.Mute{ID: uuid.Must(uuid.NewV4()), Kind: store.MuteUser, TargetID: userID, Until: now.Add(-time.Hour)}
+	s.NoError(s.store.AddMute(chatID, userMute2))
+	userMute2.ID = userMute.ID
+
+	mutes, err := s.store.ListMutes(chatID)
+	s.NoError(err)
+	s.Equal([]store.Mute{postMute, userMute2}, mutes)
+
+	deleted, err := s.store.DeleteExpiredMutes(chatID, now)
+	s.NoError(err)
+	s.Equal(1, deleted)
+
+	mutes, err = s.store.ListMutes(chatID)
+	s.NoError(err)
+	s.Equal([]store.Mute{postMute}, mutes)
+
+	s.NoError(s.store.DeleteMute(chatID, postMute.ID))
+	s.ErrorIs(s.store.DeleteMute(chatID, postMute.ID), store.ErrNotFound)
+}
+
+func (s *StoreTestSite) TestFilters() {
+	const chatID = 123
+	filter := store.Filter{ID: uuid.Must(uuid.NewV4()), Mode: store.FilterNever, Pattern: "cats?"}
+	filter2 := store.Filter{ID: uuid.Must(uuid.NewV4()), Mode: store.FilterHighlight, Pattern: "dogs"}
+
+	s.NoError(s.store.AddFilter(chatID, filter))
+	s.NoError(s.store.AddFilter(chatID, filter2))
+
+	filters, err := s.store.ListFilters(chatID)
+	s.NoError(err)
+	s.Equal([]store.Filter{filter, filter2}, filters)
+
+	s.NoError(s.store.DeleteFilter(chatID, filter.ID))
+	s.ErrorIs(s.store.DeleteFilter(chatID, filter.ID), store.ErrNotFound)
+
+	filters, err = s.store.ListFilters(chatID)
+	s.NoError(err)
+	s.Equal([]store.Filter{filter2}, filters)
+}
+
+func (s *StoreTestSite) TestReminders() {
+	const chatID = 123
+	fireAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	event := &frf.Event{Type: "post_comment", PostID: uuid.Must(uuid.NewV4()), CommentID: uuid.Must(uuid.NewV4())}
+	reminder := store.Reminder{ID: uuid.Must(uuid.NewV4()), FireAt: fireAt, Event: event, MessageID: 42}
+	reminder2 := store.Reminder{ID: uuid.Must(uuid.NewV4()), FireAt: fireAt.Add(time.Hour), Event: event}
+
+	s.NoError(s.store.AddReminder(chatID, reminder))
+	s.NoError(s.store.AddReminder(chatID, reminder2))
+
+	reminders, err := s.store.ListReminders(chatID)
+	s.NoError(err)
+	s.Equal([]store.Reminder{reminder, reminder2}, reminders)
+
+	s.NoError(s.store.DeleteReminder(chatID, reminder.ID))
+	s.ErrorIs(s.store.DeleteReminder(chatID, reminder.ID), store.ErrNotFound)
+
+	reminders, err = s.store.ListReminders(chatID)
+	s.NoError(err)
+	s.Equal([]store.Reminder{reminder2}, reminders)
+}
+
+func (s *StoreTestSite) TestSavedPosts() {
+	const chatID = 123
+	post := store.SavedPost{PostID: uuid.Must(uuid.NewV4()), Digest: "Hello"}
+	post2 := store.SavedPost{PostID: uuid.Must(uuid.NewV4()), Digest: "World"}
+
+	ok, err := s.store.IsPostSaved(chatID, post.PostID)
+	s.NoError(err)
+	s.False(ok)
+
+	s.NoError(s.store.SavePost(chatID, post))
+	s.NoError(s.store.SavePost(chatID, post2))
+	// Already saved
+	s.NoError(s.store.SavePost(chatID, post))
+
+	ok, err = s.store.IsPostSaved(chatID, post.PostID)
+	s.NoError(err)
+	s.True(ok)
+
+	posts, err := s.store.ListSavedPosts(chatID)
+	s.NoError(err)
+	s.Equal([]store.SavedPost{post2, post}, posts)
+
+	s.NoError(s.store.UnsavePost(chatID, post.PostID))
+	s.ErrorIs(s.store.UnsavePost(chatID, post.PostID), store.ErrNotFound)
+
+	posts, err = s.store.ListSavedPosts(chatID)
+	s.NoError(err)
+	s.Equal([]store.SavedPost{post2}, posts)
+}
+
+func (s *StoreTestSite) TestMaxSavedPosts() {
+	const chatID = 123
+	st := store.NewFsStore(s.dir, store.FsMaxSavedPosts(2))
+	var posts []store.SavedPost
+	for i := 0; i < 3; i++ {
+		post := store.SavedPost{PostID: uuid.Must(uuid.NewV4())}
+		s.NoError(st.SavePost(chatID, post))
+		posts = append(posts, post)
+	}
+
+	saved, err := st.ListSavedPosts(chatID)
+	s.NoError(err)
+	s.Equal([]store.SavedPost{posts[2], posts[1]}, saved)
+}
+
 // Tracked posts
 
 func (s *StoreTestSite) TestEmptyTrackedEntites() {
@@ -223,3 +645,90 @@ func (s *StoreTestSite) TestTrackedPosts() {
 	s.NoError(err)
 	s.False(ok)
 }
+
+func (s *StoreTestSite) TestUntrackOneOfPosts() {
+	const chatID = 123
+	postIDs := []uuid.UUID{uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4())}
+	for _, id := range postIDs {
+		s.NoError(s.store.TrackPost(chatID, id))
+	}
+
+	s.NoError(s.store.UntrackPost(chatID, postIDs[1]))
+
+	tracked, err := s.store.TrackedEntities(chatID)
+	s.NoError(err)
+	s.Equal([]uuid.UUID{postIDs[0], postIDs[2]}, tracked.PostIDs)
+}
+
+func (s *StoreTestSite) TestPostCommentsNotify() {
+	const chatID = 123
+	postID, _ := uuid.NewV4()
+
+	s.NoError(s.store.SetPostCommentsNotify(chatID, postID, true))
+	s.NoError(s.store.SetPostCommentsNotify(chatID, postID, true))
+
+	tracked, err := s.store.TrackedEntities(chatID)
+	s.NoError(err)
+	s.Equal([]uuid.UUID{postID}, tracked.CommentPostIDs)
+
+	s.NoError(s.store.SetPostCommentsNotify(chatID, postID, false))
+
+	tracked, err = s.store.TrackedEntities(chatID)
+	s.NoError(err)
+	s.Empty(tracked.CommentPostIDs)
+}
+
+func (s *StoreTestSite) TestTrackedFeeds() {
+	const chatID = 123
+	feed := store.TrackedFeed{ID: uuid.Must(uuid.NewV4()), UserName: "alice"}
+	feed2 := store.TrackedFeed{ID: uuid.Must(uuid.NewV4()), UserName: "cats"}
+
+	s.NoError(s.store.TrackFeed(chatID, feed))
+	s.NoError(s.store.TrackFeed(chatID, feed2))
+	s.NoError(s.store.TrackFeed(chatID, feed))
+
+	tracked, err := s.store.TrackedEntities(chatID)
+	s.NoError(err)
+	s.Equal([]store.TrackedFeed{feed, feed2}, tracked.Feeds)
+
+	s.NoError(s.store.UntrackFeed(chatID, feed.ID))
+
+	tracked, err = s.store.TrackedEntities(chatID)
+	s.NoError(err)
+	s.Equal([]uuid.UUID{feed2.ID}, tracked.FeedIDs())
+}
+
+// Benchmarks
+
+func benchmarkSentMsgRecs(b *testing.B, count int) {
+	dir := b.TempDir()
+	st := store.NewFsStore(dir, store.FsMaxSentRecords(count))
+	const chatID = 123
+	for i := 0; i < count; i++ {
+		postID, _ := uuid.NewV4()
+		if err := st.PutMsgRec(chatID, store.SentMsgRec{MessageID: i, Event: &frf.Event{PostID: postID}}); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	b.Run("Get", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := st.GetMsgRec(chatID, i%count); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Put", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if err := st.PutMsgRec(chatID, store.SentMsgRec{MessageID: count + i}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkSentMsgRecs(b *testing.B) {
+	for _, count := range []int{100, 1000, 10000} {
+		b.Run(fmt.Sprint(count), func(b *testing.B) { benchmarkSentMsgRecs(b, count) })
+	}
+}
@@ -11,7 +11,12 @@ type TgChatID = int64
 
 var ErrNotFound = errors.New("not found")
 
+// ErrChatUnreachable wraps the sending errors that mean the bot cannot write to
+// the chat anymore. The chat is deactivated when such error occurs.
+var ErrChatUnreachable = errors.New("chat is unreachable")
+
 type UserSubsPayload struct {
-	UserIDs []uuid.UUID `json:"user,omitempty"`
-	PostIDs []uuid.UUID `json:"post,omitempty"`
+	UserIDs     []uuid.UUID `json:"user,omitempty"`
+	PostIDs     []uuid.UUID `json:"post,omitempty"`
+	TimelineIDs []uuid.UUID `json:"timeline,omitempty"`
 }